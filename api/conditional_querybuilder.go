@@ -0,0 +1,61 @@
+package api
+
+// When calls fn with the builder if cond is true, so optional filters can be
+// added without breaking the method chain. Inside a WhereGroup callback the
+// conditions added by fn belong to the group.
+func (wb *WhereQueryBuilder[T, C]) When(cond bool, fn func(q T)) T {
+	q := (*wb.parent).GetQueryBuilder()
+	if cond {
+		fn(q)
+	}
+	return q
+}
+
+// Unless calls fn with the builder if cond is false.
+func (wb *WhereQueryBuilder[T, C]) Unless(cond bool, fn func(q T)) T {
+	return wb.When(!cond, fn)
+}
+
+// WhenElse calls fn if cond is true and otherwise elseFn.
+func (wb *WhereQueryBuilder[T, C]) WhenElse(cond bool, fn func(q T), elseFn func(q T)) T {
+	q := (*wb.parent).GetQueryBuilder()
+	if cond {
+		fn(q)
+	} else {
+		elseFn(q)
+	}
+	return q
+}
+
+// Tap calls fn with the builder, e.g. to apply a reusable scope.
+func (wb *WhereQueryBuilder[T, C]) Tap(fn func(q T)) T {
+	return wb.When(true, fn)
+}
+
+// When calls fn with the builder if cond is true.
+func (ib *InsertQueryBuilder) When(cond bool, fn func(q *InsertQueryBuilder)) *InsertQueryBuilder {
+	if cond {
+		fn(ib)
+	}
+	return ib
+}
+
+// Unless calls fn with the builder if cond is false.
+func (ib *InsertQueryBuilder) Unless(cond bool, fn func(q *InsertQueryBuilder)) *InsertQueryBuilder {
+	return ib.When(!cond, fn)
+}
+
+// WhenElse calls fn if cond is true and otherwise elseFn.
+func (ib *InsertQueryBuilder) WhenElse(cond bool, fn func(q *InsertQueryBuilder), elseFn func(q *InsertQueryBuilder)) *InsertQueryBuilder {
+	if cond {
+		fn(ib)
+	} else {
+		elseFn(ib)
+	}
+	return ib
+}
+
+// Tap calls fn with the builder.
+func (ib *InsertQueryBuilder) Tap(fn func(q *InsertQueryBuilder)) *InsertQueryBuilder {
+	return ib.When(true, fn)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/faciam-dev/goquent-query-builder/executor"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 	"github.com/faciam-dev/goquent-query-builder/internal/query"
 )
@@ -10,12 +11,18 @@ type DeleteQueryBuilder struct {
 	JoinQueryBuilder[*DeleteQueryBuilder, query.DeleteBuilder]
 	OrderByQueryBuilder[*DeleteQueryBuilder, query.DeleteBuilder]
 	builder *query.DeleteBuilder
+	queryer executor.Queryer
 	QueryBuilderStrategy[DeleteQueryBuilder, query.DeleteBuilder]
 }
 
 func NewDeleteQueryBuilder(strategy interfaces.QueryBuilderStrategy) *DeleteQueryBuilder {
+	return newDeleteQueryBuilder(query.NewDeleteBuilder(strategy))
+}
+
+func newDeleteQueryBuilder(builder *query.DeleteBuilder) *DeleteQueryBuilder {
+	strategy := builder.GetStrategy()
 	db := &DeleteQueryBuilder{
-		builder: query.NewDeleteBuilder(strategy),
+		builder: builder,
 	}
 
 	whereBuilder := NewWhereQueryBuilder[*DeleteQueryBuilder, query.DeleteBuilder](strategy)
@@ -33,26 +40,102 @@ func NewDeleteQueryBuilder(strategy interfaces.QueryBuilderStrategy) *DeleteQuer
 	return db
 }
 
+// Clone returns a copy of the builder with the same strategy and queryer that
+// can be extended and run independently of qb.
+func (qb *DeleteQueryBuilder) Clone() *DeleteQueryBuilder {
+	c := newDeleteQueryBuilder(qb.builder.Clone())
+	c.queryer = qb.queryer
+	return c
+}
+
 func (qb *DeleteQueryBuilder) Delete() *DeleteQueryBuilder {
 	qb.builder.Delete()
 
 	return qb
 }
 
-// Using
-/*
-func (qb *UpdateQueryBuilder) Using(qb *QueryBuilder) *DeleteQueryBuilder {
-	qb.builder.Using(qb)
+// Using adds a table the delete reads from besides its target. PostgreSQL
+// lists it in USING, MySQL and SQL Server after the target in FROM. Relate it
+// to the target in the WHERE clause, for example with WhereColumn.
+func (qb *DeleteQueryBuilder) Using(table string) *DeleteQueryBuilder {
+	qb.builder.Using(table)
+	return qb
+}
 
-	return ub
+// UsingSub adds a subquery, named alias, the delete reads from besides its
+// target.
+func (qb *DeleteQueryBuilder) UsingSub(sb *SelectQueryBuilder, alias string) *DeleteQueryBuilder {
+	qb.builder.UsingSub(sb.builder, alias)
+	return qb
 }
-*/
 
 func (qb *DeleteQueryBuilder) Table(table string) *DeleteQueryBuilder {
 	qb.builder.Table(table)
 	return qb
 }
 
+// Scope applies the named scopes registered for the table with
+// RegisterScope. It must be called after Table.
+func (qb *DeleteQueryBuilder) Scope(names ...string) *DeleteQueryBuilder {
+	qb.builder.Scope(names...)
+	return qb
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (qb *DeleteQueryBuilder) WithoutGlobalScope(names ...string) *DeleteQueryBuilder {
+	qb.builder.WithoutGlobalScope(names...)
+	return qb
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (qb *DeleteQueryBuilder) WithoutGlobalScopes() *DeleteQueryBuilder {
+	qb.builder.WithoutGlobalScopes()
+	return qb
+}
+
+// Limit limits the number of rows deleted. MySQL renders LIMIT and SQL Server
+// TOP. PostgreSQL and SQLite, and SQL Server with an order, match the rows in
+// a subquery by the column of LimitKey, which defaults to ctid and rowid.
+// Build returns ErrLimitNotSupported for a limited delete of joined tables.
+func (qb *DeleteQueryBuilder) Limit(limit int64) *DeleteQueryBuilder {
+	qb.builder.Limit(limit)
+	return qb
+}
+
+// LimitKey sets the column, usually the primary key, matching the rows of a
+// Limit emulated with a subquery.
+func (qb *DeleteQueryBuilder) LimitKey(column string) *DeleteQueryBuilder {
+	qb.builder.LimitKey(column)
+	return qb
+}
+
+// Returning sets the columns returned for the deleted rows. PostgreSQL and
+// SQLite use RETURNING, SQL Server uses OUTPUT and MySQL reports
+// ErrReturningNotSupported.
+func (qb *DeleteQueryBuilder) Returning(columns ...string) *DeleteQueryBuilder {
+	qb.builder.Returning(columns...)
+	return qb
+}
+
+// With adds a common table expression.
+func (qb *DeleteQueryBuilder) With(name string, sb *SelectQueryBuilder) *DeleteQueryBuilder {
+	qb.builder.With(name, sb.builder)
+	return qb
+}
+
+// WithRecursive adds a recursive common table expression whose anchor and
+// recursive parts are combined with UNION ALL.
+func (qb *DeleteQueryBuilder) WithRecursive(name string, columns []string, anchor *SelectQueryBuilder, recursive *SelectQueryBuilder) *DeleteQueryBuilder {
+	qb.builder.WithRecursive(name, columns, anchor.builder, recursive.builder)
+	return qb
+}
+
+// WithMaterialized adds a common table expression with the MATERIALIZED hint.
+func (qb *DeleteQueryBuilder) WithMaterialized(name string, sb *SelectQueryBuilder) *DeleteQueryBuilder {
+	qb.builder.WithMaterialized(name, sb.builder)
+	return qb
+}
+
 func (ub *DeleteQueryBuilder) Dump() (string, []interface{}, error) {
 	b := query.NewDebugBuilder[*query.DeleteBuilder, DeleteQueryBuilder](ub.builder)
 
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+	"github.com/faciam-dev/goquent-query-builder/internal/query"
+)
+
+// ErrReturningNotSupported is returned by Build when Returning is used with a
+// dialect that cannot return the affected rows.
+var ErrReturningNotSupported = base.ErrReturningNotSupported
+
+// ErrSchemaUnsupported is returned by SchemaBuilder.Build for DDL the dialect
+// cannot express.
+var ErrSchemaUnsupported = base.ErrSchemaUnsupported
+
+// ErrInvalidPerPage is returned when a page size below one is requested.
+var ErrInvalidPerPage = query.ErrInvalidPerPage
+
+// Errors reported by Build for CursorPaginate.
+var (
+	ErrCursorNoOrder       = query.ErrCursorNoOrder
+	ErrCursorRawOrder      = query.ErrCursorRawOrder
+	ErrCursorNoTiebreaker  = query.ErrCursorNoTiebreaker
+	ErrCursorInvalid       = query.ErrCursorInvalid
+	ErrCursorOrderMismatch = query.ErrCursorOrderMismatch
+)
+
+// ErrUnknownScope is returned by Build when Scope names a scope that is not
+// registered for the table of the query.
+var ErrUnknownScope = query.ErrUnknownScope
+
+// ErrNoSoftDeletes is returned by Build for SoftDelete and Restore on a table
+// registered without RegisterSoftDeletes.
+var ErrNoSoftDeletes = query.ErrNoSoftDeletes
+
+// ErrNonNumericAmount is returned by Build when Increment, Decrement or
+// IncrementEach is given an amount that is not a number.
+var ErrNonNumericAmount = query.ErrNonNumericAmount
+
+// ErrJSONArithmeticNotSupported is returned by Build for Increment or
+// Decrement of a JSON path on a dialect that cannot express it.
+var ErrJSONArithmeticNotSupported = base.ErrJSONArithmeticNotSupported
+
+// ErrJoinNotSupported is returned by Build for a join of an UPDATE or DELETE
+// the dialect cannot express, such as a LEFT JOIN in a PostgreSQL UPDATE or
+// any join in a SQLite DELETE.
+var ErrJoinNotSupported = base.ErrJoinNotSupported
+
+// ErrLimitNotSupported is returned by Build for a Limit of an UPDATE or DELETE
+// the dialect cannot express, such as a limited delete of joined tables.
+var ErrLimitNotSupported = base.ErrLimitNotSupported
+
+// ErrRowTooLarge is returned by BuildChunked for a row that alone exceeds the
+// limits of a statement.
+var ErrRowTooLarge = query.ErrRowTooLarge
+
+// ErrCTEWithoutSelect is returned by Build for With or WithRecursive on an
+// insert that is not an InsertUsing.
+var ErrCTEWithoutSelect = query.ErrCTEWithoutSelect
+
+// ErrDuplicateCTE is returned by Build for two different common table
+// expressions of the same name in the queries of a Union.
+var ErrDuplicateCTE = base.ErrDuplicateCTE
+
+// ErrNoKey is returned by Build for UpdateStruct with a struct without pk
+// fields when no Where condition limits the update.
+var ErrNoKey = query.ErrNoKey
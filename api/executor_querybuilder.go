@@ -0,0 +1,190 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/faciam-dev/goquent-query-builder/executor"
+	"github.com/faciam-dev/goquent-query-builder/hook"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// SetQueryer sets the *sql.DB, *sql.Tx or *sql.Conn used by the terminal methods.
+func (qb *SelectQueryBuilder) SetQueryer(q executor.Queryer) *SelectQueryBuilder {
+	qb.queryer = q
+	return qb
+}
+
+// Get runs the query and returns all rows.
+func (qb *SelectQueryBuilder) Get(ctx context.Context) ([]map[string]interface{}, error) {
+	query, values, err := qb.buildForQueryer()
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.Query(ctx, qb.runner(qb.queryer), query, values...)
+}
+
+// Scan runs the query and fills dest, a pointer to a slice of structs, from
+// the db tags of the struct.
+func (qb *SelectQueryBuilder) Scan(ctx context.Context, dest any) error {
+	query, values, err := qb.buildForQueryer()
+	if err != nil {
+		return err
+	}
+
+	return executor.QueryStructs(ctx, qb.runner(qb.queryer), dest, query, values...)
+}
+
+// First runs the query limited to one row and returns it. sql.ErrNoRows is
+// returned when there is no row.
+func (qb *SelectQueryBuilder) First(ctx context.Context) (map[string]interface{}, error) {
+	query, values, err := qb.buildSelection(nil, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := executor.Query(ctx, qb.runner(qb.queryer), query, values...)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return rows[0], nil
+}
+
+// Value returns a single column of the first row. sql.ErrNoRows is returned
+// when there is no row.
+func (qb *SelectQueryBuilder) Value(ctx context.Context, column string) (interface{}, error) {
+	values, err := qb.pluck(ctx, column, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return values[0], nil
+}
+
+// Pluck returns a single column of every row.
+func (qb *SelectQueryBuilder) Pluck(ctx context.Context, column string) ([]interface{}, error) {
+	return qb.pluck(ctx, column, 0)
+}
+
+// Exists reports whether the query returns any row. The query is run without
+// its lock.
+func (qb *SelectQueryBuilder) Exists(ctx context.Context) (bool, error) {
+	query, values, err := qb.builder.BuildExists()
+	if err != nil {
+		return false, err
+	}
+
+	result, err := executor.QueryColumn(ctx, qb.runner(qb.queryer), query, values...)
+	if err != nil {
+		return false, err
+	}
+	if len(result) == 0 {
+		return false, nil
+	}
+
+	n, err := executor.ToInt64(result[0])
+	return n == 1, err
+}
+
+// Count returns the number of rows the query returns, ignoring its ORDER BY,
+// LIMIT, OFFSET and lock as Paginate does. Use SelectCount to add a COUNT
+// column to the selection instead.
+func (qb *SelectQueryBuilder) Count(ctx context.Context) (int64, error) {
+	query, values, err := qb.builder.BuildCount()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := executor.QueryColumn(ctx, qb.runner(qb.queryer), query, values...)
+	if err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return executor.ToInt64(result[0])
+}
+
+// runner returns q wrapped with the execution hooks of the strategy.
+func (qb *SelectQueryBuilder) runner(q executor.Queryer) executor.Queryer {
+	return qb.builder.GetStrategy().Hooks().Wrap(q, hook.KindSelect)
+}
+
+// buildForQueryer builds the query run by the terminal methods.
+func (qb *SelectQueryBuilder) buildForQueryer() (string, []interface{}, error) {
+	qb.warnLockOutsideTransaction()
+	return qb.Build()
+}
+
+// buildSelection builds the query run by the terminal methods with the
+// columns and limit replaced, leaving the builder unchanged. A nil columns or
+// zero limit keeps the current value.
+func (qb *SelectQueryBuilder) buildSelection(columns []structs.Column, limit int64) (string, []interface{}, error) {
+	qb.warnLockOutsideTransaction()
+	return qb.builder.BuildSelection(columns, limit)
+}
+
+// pluck returns column of the rows, at most limit of them unless it is zero.
+func (qb *SelectQueryBuilder) pluck(ctx context.Context, column string, limit int64) ([]interface{}, error) {
+	query, values, err := qb.buildSelection([]structs.Column{{Name: column}}, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return executor.QueryColumn(ctx, qb.runner(qb.queryer), query, values...)
+}
+
+// SetQueryer sets the *sql.DB, *sql.Tx or *sql.Conn used by Exec.
+func (ib *InsertQueryBuilder) SetQueryer(q executor.Queryer) *InsertQueryBuilder {
+	ib.queryer = q
+	return ib
+}
+
+// Exec runs the INSERT statement.
+func (ib *InsertQueryBuilder) Exec(ctx context.Context) (executor.Result, error) {
+	query, values, err := ib.Build()
+	if err != nil {
+		return executor.Result{}, err
+	}
+
+	return executor.Exec(ctx, ib.builder.GetStrategy().Hooks().Wrap(ib.queryer, hook.KindInsert), query, values...)
+}
+
+// SetQueryer sets the *sql.DB, *sql.Tx or *sql.Conn used by Exec.
+func (ub *UpdateQueryBuilder) SetQueryer(q executor.Queryer) *UpdateQueryBuilder {
+	ub.queryer = q
+	return ub
+}
+
+// Exec runs the UPDATE statement.
+func (ub *UpdateQueryBuilder) Exec(ctx context.Context) (executor.Result, error) {
+	query, values, err := ub.Build()
+	if err != nil {
+		return executor.Result{}, err
+	}
+
+	return executor.Exec(ctx, ub.builder.GetStrategy().Hooks().Wrap(ub.queryer, hook.KindUpdate), query, values...)
+}
+
+// SetQueryer sets the *sql.DB, *sql.Tx or *sql.Conn used by Exec.
+func (qb *DeleteQueryBuilder) SetQueryer(q executor.Queryer) *DeleteQueryBuilder {
+	qb.queryer = q
+	return qb
+}
+
+// Exec runs the DELETE statement.
+func (qb *DeleteQueryBuilder) Exec(ctx context.Context) (executor.Result, error) {
+	query, values, err := qb.Build()
+	if err != nil {
+		return executor.Result{}, err
+	}
+
+	return executor.Exec(ctx, qb.builder.GetStrategy().Hooks().Wrap(qb.queryer, hook.KindDelete), query, values...)
+}
@@ -0,0 +1,18 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// Expression is a value rendered as SQL instead of a placeholder.
+type Expression = structs.Expression
+
+// Raw returns an expression for sql, with bindings bound to its ? placeholders
+// in the form of the dialect. It can be used as a value of Insert,
+// InsertBatch, Upsert, UpsertSet, Update and Where:
+//
+//	Update(map[string]interface{}{"views": api.Raw("views + ?", 1)})
+//	Where("expires_at", "<", api.Raw("NOW() - INTERVAL 1 DAY"))
+func Raw(sql string, bindings ...interface{}) Expression {
+	return Expression{SQL: sql, Values: bindings}
+}
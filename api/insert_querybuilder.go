@@ -1,12 +1,14 @@
 package api
 
 import (
+	"github.com/faciam-dev/goquent-query-builder/executor"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 	"github.com/faciam-dev/goquent-query-builder/internal/query"
 )
 
 type InsertQueryBuilder struct {
 	builder *query.InsertBuilder
+	queryer executor.Queryer
 }
 
 func NewInsertQueryBuilder(strategy interfaces.QueryBuilderStrategy) *InsertQueryBuilder {
@@ -15,6 +17,15 @@ func NewInsertQueryBuilder(strategy interfaces.QueryBuilderStrategy) *InsertQuer
 	}
 }
 
+// Clone returns a copy of the builder with the same strategy and queryer that
+// can be extended and run independently of ib.
+func (ib *InsertQueryBuilder) Clone() *InsertQueryBuilder {
+	return &InsertQueryBuilder{
+		builder: ib.builder.Clone(),
+		queryer: ib.queryer,
+	}
+}
+
 func (ib *InsertQueryBuilder) Table(table string) *InsertQueryBuilder {
 	ib.builder.Table(table)
 	return ib
@@ -30,6 +41,32 @@ func (ib *InsertQueryBuilder) InsertBatch(data []map[string]interface{}) *Insert
 	return ib
 }
 
+// ChunkOptions limits the statements of BuildChunked.
+type ChunkOptions = query.ChunkOptions
+
+// Statement is a built query with its bound values.
+type Statement = query.Statement
+
+// InsertBatchChunked builds rows as a batch insert split into statements that
+// stay within the limits of opts, such as the bind parameter limit of the
+// dialect.
+func (ib *InsertQueryBuilder) InsertBatchChunked(rows []map[string]interface{}, opts ChunkOptions) ([]Statement, error) {
+	return ib.builder.InsertBatch(rows).BuildChunked(opts)
+}
+
+// InsertStruct inserts a row from a struct using its db tags. Fields tagged
+// readonly are skipped, as are zero valued pk and omitempty fields.
+func (ib *InsertQueryBuilder) InsertStruct(v any) *InsertQueryBuilder {
+	ib.builder.InsertStruct(v)
+	return ib
+}
+
+// InsertStructs inserts a row for every struct in a slice.
+func (ib *InsertQueryBuilder) InsertStructs(slice any) *InsertQueryBuilder {
+	ib.builder.InsertStructs(slice)
+	return ib
+}
+
 func (ib *InsertQueryBuilder) InsertOrIgnore(data []map[string]interface{}) *InsertQueryBuilder {
 	ib.builder.InsertOrIgnore(data)
 	return ib
@@ -40,6 +77,14 @@ func (ib *InsertQueryBuilder) Upsert(data []map[string]interface{}, unique []str
 	return ib
 }
 
+// UpsertSet sets columns of the conflicting rows of an upsert to values, such
+// as api.Raw expressions, instead of the inserted values. It is called after
+// Upsert.
+func (ib *InsertQueryBuilder) UpsertSet(values map[string]interface{}) *InsertQueryBuilder {
+	ib.builder.UpsertSet(values)
+	return ib
+}
+
 func (ib *InsertQueryBuilder) UpdateOrInsert(condition map[string]interface{}, values map[string]interface{}) *InsertQueryBuilder {
 	ib.builder.UpdateOrInsert(condition, values)
 	return ib
@@ -50,6 +95,34 @@ func (ib *InsertQueryBuilder) InsertUsing(columns []string, qb *SelectQueryBuild
 	return ib
 }
 
+// Returning sets the columns returned for the inserted rows. PostgreSQL and
+// SQLite use RETURNING, SQL Server uses OUTPUT and MySQL reports
+// ErrReturningNotSupported.
+func (ib *InsertQueryBuilder) Returning(columns ...string) *InsertQueryBuilder {
+	ib.builder.Returning(columns...)
+	return ib
+}
+
+// With adds a common table expression to the SELECT of InsertUsing.
+func (ib *InsertQueryBuilder) With(name string, sb *SelectQueryBuilder) *InsertQueryBuilder {
+	ib.builder.With(name, sb.builder)
+	return ib
+}
+
+// WithRecursive adds a recursive common table expression to the SELECT of
+// InsertUsing.
+func (ib *InsertQueryBuilder) WithRecursive(name string, columns []string, anchor *SelectQueryBuilder, recursive *SelectQueryBuilder) *InsertQueryBuilder {
+	ib.builder.WithRecursive(name, columns, anchor.builder, recursive.builder)
+	return ib
+}
+
+// WithMaterialized adds a materialized common table expression to the SELECT
+// of InsertUsing.
+func (ib *InsertQueryBuilder) WithMaterialized(name string, sb *SelectQueryBuilder) *InsertQueryBuilder {
+	ib.builder.WithMaterialized(name, sb.builder)
+	return ib
+}
+
 func (ib *InsertQueryBuilder) Dump() (string, []interface{}, error) {
 	b := query.NewDebugBuilder[*query.InsertBuilder, InsertQueryBuilder](ib.builder)
 
@@ -65,3 +138,14 @@ func (ib *InsertQueryBuilder) RawSql() (string, error) {
 func (ib *InsertQueryBuilder) Build() (string, []interface{}, error) {
 	return ib.builder.Build()
 }
+
+// BuildChunked builds the rows of InsertBatch, InsertOrIgnore or Upsert into
+// as many statements as the limits of opts require. Zero limits take the
+// defaults of the dialect: 65535 bound values on MySQL and PostgreSQL, 32766
+// on SQLite and 2100 with 1000 rows on SQL Server, and 4 MiB on MySQL. Every
+// statement lists the same columns in the same order, and no rows build no
+// statements. BuildChunked returns ErrRowTooLarge for a row that alone
+// exceeds the limits.
+func (ib *InsertQueryBuilder) BuildChunked(opts ChunkOptions) ([]Statement, error) {
+	return ib.builder.BuildChunked(opts)
+}
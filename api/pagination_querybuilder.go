@@ -0,0 +1,191 @@
+package api
+
+import (
+	"context"
+
+	"github.com/faciam-dev/goquent-query-builder/executor"
+)
+
+// Pagination is one page of rows. Total and LastPage are only set by
+// Paginate; SimplePaginate leaves them zero.
+type Pagination struct {
+	Items       []map[string]interface{}
+	Total       int64
+	PerPage     int64
+	CurrentPage int64
+	LastPage    int64
+	HasNextPage bool
+	HasPrevPage bool
+}
+
+// PaginationQuery holds the queries Paginate would run, for callers with
+// their own executor.
+type PaginationQuery struct {
+	Query       string
+	Values      []interface{}
+	CountQuery  string
+	CountValues []interface{}
+}
+
+// PaginateSQL builds the query for a page and the query counting all rows
+// without running them. Pages start at 1.
+func (qb *SelectQueryBuilder) PaginateSQL(page, perPage int64) (*PaginationQuery, error) {
+	if perPage < 1 {
+		return nil, ErrInvalidPerPage
+	}
+	page = max(page, 1)
+
+	countQuery, countValues, err := qb.builder.BuildCount()
+	if err != nil {
+		return nil, err
+	}
+
+	query, values, err := qb.builder.BuildPage(perPage, (page-1)*perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PaginationQuery{
+		Query:       query,
+		Values:      values,
+		CountQuery:  countQuery,
+		CountValues: countValues,
+	}, nil
+}
+
+// Paginate returns a page of rows along with the total number of rows. When
+// queryer is nil the one set with SetQueryer is used.
+func (qb *SelectQueryBuilder) Paginate(ctx context.Context, queryer executor.Queryer, page, perPage int64) (*Pagination, error) {
+	if queryer == nil {
+		queryer = qb.queryer
+	}
+	queryer = qb.runner(queryer)
+
+	pq, err := qb.PaginateSQL(page, perPage)
+	if err != nil {
+		return nil, err
+	}
+	page = max(page, 1)
+
+	count, err := executor.QueryColumn(ctx, queryer, pq.CountQuery, pq.CountValues...)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	if len(count) > 0 {
+		if total, err = executor.ToInt64(count[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	items := []map[string]interface{}{}
+	// skip the page query when it is past the end
+	if (page-1)*perPage < total {
+		if items, err = executor.Query(ctx, queryer, pq.Query, pq.Values...); err != nil {
+			return nil, err
+		}
+	}
+
+	lastPage := max((total+perPage-1)/perPage, 1)
+
+	return &Pagination{
+		Items:       items,
+		Total:       total,
+		PerPage:     perPage,
+		CurrentPage: page,
+		LastPage:    lastPage,
+		HasNextPage: page < lastPage,
+		HasPrevPage: page > 1,
+	}, nil
+}
+
+// SimplePaginate returns a page of rows without counting them. One extra row
+// is fetched to find out whether there is a next page.
+func (qb *SelectQueryBuilder) SimplePaginate(ctx context.Context, queryer executor.Queryer, page, perPage int64) (*Pagination, error) {
+	if queryer == nil {
+		queryer = qb.queryer
+	}
+	queryer = qb.runner(queryer)
+	if perPage < 1 {
+		return nil, ErrInvalidPerPage
+	}
+	page = max(page, 1)
+
+	query, values, err := qb.builder.BuildPage(perPage+1, (page-1)*perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := executor.Query(ctx, queryer, query, values...)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := int64(len(items)) > perPage
+	if hasNext {
+		items = items[:perPage]
+	}
+
+	return &Pagination{
+		Items:       items,
+		PerPage:     perPage,
+		CurrentPage: page,
+		HasNextPage: hasNext,
+		HasPrevPage: page > 1,
+	}, nil
+}
+
+// CursorPagination is one page of a cursor paginated query. NextCursor is
+// empty on the last page.
+type CursorPagination struct {
+	Items      []map[string]interface{}
+	PerPage    int
+	NextCursor string
+}
+
+// CursorPaginate limits the query to the perPage rows following cursor,
+// using a keyset predicate on the ORDER BY columns. An empty cursor starts at
+// the first page. perPage+1 rows are selected so that a next page can be
+// detected. The ORDER BY must contain a unique tiebreaker column, "id" unless
+// set with CursorTiebreaker, and Build fails otherwise.
+func (qb *SelectQueryBuilder) CursorPaginate(perPage int, cursor string) *SelectQueryBuilder {
+	qb.builder.CursorPaginate(perPage, cursor)
+	return qb
+}
+
+// CursorTiebreaker sets the unique column that CursorPaginate requires in the
+// ORDER BY.
+func (qb *SelectQueryBuilder) CursorTiebreaker(column string) *SelectQueryBuilder {
+	qb.builder.CursorTiebreaker(column)
+	return qb
+}
+
+// EncodeCursor returns the cursor of the page following row, for callers
+// running the query themselves.
+func (qb *SelectQueryBuilder) EncodeCursor(row map[string]interface{}) (string, error) {
+	return qb.builder.EncodeCursor(row)
+}
+
+// CursorPage runs a query set up with CursorPaginate and returns the page
+// with the cursor of the next one.
+func (qb *SelectQueryBuilder) CursorPage(ctx context.Context) (*CursorPagination, error) {
+	perPage := qb.builder.CursorPerPage()
+	if perPage < 1 {
+		return nil, ErrInvalidPerPage
+	}
+
+	items, err := qb.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &CursorPagination{Items: items, PerPage: perPage}
+	if len(items) > perPage {
+		p.Items = items[:perPage]
+		if p.NextCursor, err = qb.EncodeCursor(p.Items[perPage-1]); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/internal/query"
+)
+
+// Blueprint collects the columns, indexes and foreign keys of a table.
+type Blueprint = query.Blueprint
+
+// ColumnDefinition modifies a column added to a Blueprint.
+type ColumnDefinition = query.ColumnDefinition
+
+// ForeignKeyDefinition sets the referenced table, columns and actions of a
+// foreign key.
+type ForeignKeyDefinition = query.ForeignKeyDefinition
+
+type SchemaBuilder struct {
+	builder *query.SchemaBuilder
+}
+
+func NewSchemaBuilder(strategy interfaces.QueryBuilderStrategy) *SchemaBuilder {
+	return &SchemaBuilder{
+		builder: query.NewSchemaBuilder(strategy),
+	}
+}
+
+// CreateTable
+func (sb *SchemaBuilder) CreateTable(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	sb.builder.CreateTable(table, fn)
+	return sb
+}
+
+// CreateTableIfNotExists
+func (sb *SchemaBuilder) CreateTableIfNotExists(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	sb.builder.CreateTableIfNotExists(table, fn)
+	return sb
+}
+
+// AlterTable
+func (sb *SchemaBuilder) AlterTable(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	sb.builder.AlterTable(table, fn)
+	return sb
+}
+
+// DropTable
+func (sb *SchemaBuilder) DropTable(table string) *SchemaBuilder {
+	sb.builder.DropTable(table)
+	return sb
+}
+
+// DropTableIfExists
+func (sb *SchemaBuilder) DropTableIfExists(table string) *SchemaBuilder {
+	sb.builder.DropTableIfExists(table)
+	return sb
+}
+
+// RenameTable
+func (sb *SchemaBuilder) RenameTable(from string, to string) *SchemaBuilder {
+	sb.builder.RenameTable(from, to)
+	return sb
+}
+
+// Build returns the DDL statements in the order they were added.
+func (sb *SchemaBuilder) Build() ([]string, error) {
+	return sb.builder.Build()
+}
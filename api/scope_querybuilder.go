@@ -0,0 +1,64 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/internal/query"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+)
+
+// ScopeQueryBuilder collects the conditions of a scope with the where methods
+// of the other builders.
+type ScopeQueryBuilder struct {
+	WhereQueryBuilder[*ScopeQueryBuilder, query.ScopeBuilder]
+	builder *query.ScopeBuilder
+}
+
+func newScopeQueryBuilder(strategy interfaces.QueryBuilderStrategy) *ScopeQueryBuilder {
+	sb := &ScopeQueryBuilder{
+		builder: query.NewScopeBuilder(strategy),
+	}
+
+	whereBuilder := NewWhereQueryBuilder[*ScopeQueryBuilder, query.ScopeBuilder](strategy)
+	whereBuilder.SetParent(&sb)
+	sb.WhereQueryBuilder = *whereBuilder
+
+	return sb
+}
+
+// RegisterScope registers a named scope for table on strategy. Select, Update
+// and Delete builders apply it with Scope(name).
+func RegisterScope(strategy interfaces.QueryBuilderStrategy, table, name string, fn func(q *ScopeQueryBuilder)) {
+	strategy.Scopes().Register(table, name, scopeFunc(strategy, fn))
+}
+
+// RegisterGlobalScope registers a scope that every Select, Update and Delete
+// builder created with strategy applies to table, unless it opts out with
+// WithoutGlobalScope(name).
+func RegisterGlobalScope(strategy interfaces.QueryBuilderStrategy, table, name string, fn func(q *ScopeQueryBuilder)) {
+	strategy.Scopes().RegisterGlobal(table, name, scopeFunc(strategy, fn))
+}
+
+func scopeFunc(strategy interfaces.QueryBuilderStrategy, fn func(q *ScopeQueryBuilder)) scope.Func {
+	return func() []structs.WhereGroup {
+		sb := newScopeQueryBuilder(strategy)
+		fn(sb)
+		return sb.builder.Groups()
+	}
+}
+
+func (sb *ScopeQueryBuilder) GetQueryBuilder() *ScopeQueryBuilder {
+	return sb
+}
+
+func (sb *ScopeQueryBuilder) GetWhereBuilder() *query.WhereBuilder[query.ScopeBuilder] {
+	return sb.builder.GetWhereBuilder()
+}
+
+func (sb *ScopeQueryBuilder) GetJoinBuilder() *query.JoinBuilder[query.ScopeBuilder] {
+	return sb.builder.GetJoinBuilder()
+}
+
+func (sb *ScopeQueryBuilder) GetOrderByBuilder() *query.OrderByBuilder[query.ScopeBuilder] {
+	return sb.builder.GetOrderByBuilder()
+}
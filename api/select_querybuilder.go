@@ -1,6 +1,9 @@
 package api
 
 import (
+	"slices"
+
+	"github.com/faciam-dev/goquent-query-builder/executor"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 	"github.com/faciam-dev/goquent-query-builder/internal/query"
@@ -19,15 +22,20 @@ type SelectQueryBuilder struct {
 	OrderByQueryBuilder[*SelectQueryBuilder, query.SelectBuilder]
 	builder *query.SelectBuilder
 	Queries *[]structs.Query
+	queryer executor.Queryer
 	QueryBuilderStrategy[SelectQueryBuilder, query.SelectBuilder]
 }
 
 func NewSelectQueryBuilder(strategy interfaces.QueryBuilderStrategy) *SelectQueryBuilder {
+	return newSelectQueryBuilder(query.NewSelectBuilder(strategy), &[]structs.Query{})
+}
+
+func newSelectQueryBuilder(builder *query.SelectBuilder, queries *[]structs.Query) *SelectQueryBuilder {
+	strategy := builder.GetStrategy()
 	sb := &SelectQueryBuilder{
-		//WhereQueryBuilder: *NewWhereQueryBuilder[SelectBuilder, query.Builder](strategy),
-		builder: query.NewSelectBuilder(strategy),
+		builder: builder,
+		Queries: queries,
 	}
-	sb.Queries = &[]structs.Query{}
 
 	whereBuilder := NewWhereQueryBuilder[*SelectQueryBuilder, query.SelectBuilder](strategy)
 	whereBuilder.SetParent(&sb)
@@ -44,11 +52,59 @@ func NewSelectQueryBuilder(strategy interfaces.QueryBuilderStrategy) *SelectQuer
 	return sb
 }
 
+// Clone returns a copy of the builder with the same strategy and queryer. The
+// copy can be extended and run independently of qb, also from another
+// goroutine, e.g. by handlers deriving their queries from a shared base query.
+func (qb *SelectQueryBuilder) Clone() *SelectQueryBuilder {
+	queries := slices.Clone(*qb.Queries)
+	c := newSelectQueryBuilder(qb.builder.Clone(), &queries)
+	c.queryer = qb.queryer
+	return c
+}
+
 func (qb *SelectQueryBuilder) Table(table string) *SelectQueryBuilder {
 	qb.builder.Table(table)
 	return qb
 }
 
+// Scope applies the named scopes registered for the table with
+// RegisterScope. It must be called after Table.
+func (qb *SelectQueryBuilder) Scope(names ...string) *SelectQueryBuilder {
+	qb.builder.Scope(names...)
+	return qb
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (qb *SelectQueryBuilder) WithoutGlobalScope(names ...string) *SelectQueryBuilder {
+	qb.builder.WithoutGlobalScope(names...)
+	return qb
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (qb *SelectQueryBuilder) WithoutGlobalScopes() *SelectQueryBuilder {
+	qb.builder.WithoutGlobalScopes()
+	return qb
+}
+
+// With adds a common table expression.
+func (qb *SelectQueryBuilder) With(name string, sb *SelectQueryBuilder) *SelectQueryBuilder {
+	qb.builder.With(name, sb.builder)
+	return qb
+}
+
+// WithRecursive adds a recursive common table expression whose anchor and
+// recursive parts are combined with UNION ALL.
+func (qb *SelectQueryBuilder) WithRecursive(name string, columns []string, anchor *SelectQueryBuilder, recursive *SelectQueryBuilder) *SelectQueryBuilder {
+	qb.builder.WithRecursive(name, columns, anchor.builder, recursive.builder)
+	return qb
+}
+
+// WithMaterialized adds a common table expression with the MATERIALIZED hint.
+func (qb *SelectQueryBuilder) WithMaterialized(name string, sb *SelectQueryBuilder) *SelectQueryBuilder {
+	qb.builder.WithMaterialized(name, sb.builder)
+	return qb
+}
+
 func (qb *SelectQueryBuilder) Select(columns ...string) *SelectQueryBuilder {
 	qb.builder.Select(columns...)
 	return qb
@@ -59,7 +115,9 @@ func (qb *SelectQueryBuilder) SelectRaw(raw string, value ...interface{}) *Selec
 	return qb
 }
 
-func (qb *SelectQueryBuilder) Count(columns ...string) *SelectQueryBuilder {
+// SelectCount adds COUNT of columns, or COUNT(*) without columns, to the
+// selection. Count runs the query and returns the number of rows.
+func (qb *SelectQueryBuilder) SelectCount(columns ...string) *SelectQueryBuilder {
 	qb.builder.Count(columns...)
 	return qb
 }
@@ -84,6 +142,46 @@ func (qb *SelectQueryBuilder) Avg(column string) *SelectQueryBuilder {
 	return qb
 }
 
+// SelectWindow adds a window function to the select list, e.g.
+//
+//	SelectWindow("ROW_NUMBER()", "rn", func(w *WindowBuilder) {
+//		w.PartitionBy("department_id").OrderBy("salary", "desc")
+//	})
+func (qb *SelectQueryBuilder) SelectWindow(fn string, alias string, spec func(w *WindowBuilder)) *SelectQueryBuilder {
+	qb.builder.SelectWindow(fn, alias, func(b *query.WindowBuilder) {
+		if spec != nil {
+			spec(&WindowBuilder{builder: b})
+		}
+	})
+	return qb
+}
+
+// SelectWindowRaw adds a window function written as raw SQL, for arguments
+// SelectWindow does not accept such as DISTINCT or expressions, e.g.
+//
+//	SelectWindowRaw("SUM(DISTINCT amount)", "total", func(w *WindowBuilder) {
+//		w.PartitionBy("customer_id")
+//	})
+func (qb *SelectQueryBuilder) SelectWindowRaw(raw string, alias string, spec func(w *WindowBuilder)) *SelectQueryBuilder {
+	qb.builder.SelectWindowRaw(raw, alias, func(b *query.WindowBuilder) {
+		if spec != nil {
+			spec(&WindowBuilder{builder: b})
+		}
+	})
+	return qb
+}
+
+// Window adds a named window that SelectWindow can refer to with
+// WindowBuilder.Window.
+func (qb *SelectQueryBuilder) Window(name string, spec func(w *WindowBuilder)) *SelectQueryBuilder {
+	qb.builder.Window(name, func(b *query.WindowBuilder) {
+		if spec != nil {
+			spec(&WindowBuilder{builder: b})
+		}
+	})
+	return qb
+}
+
 func (qb *SelectQueryBuilder) Distinct(column ...string) *SelectQueryBuilder {
 	qb.builder.Distinct(column...)
 	return qb
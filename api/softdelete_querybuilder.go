@@ -0,0 +1,68 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// RegisterSoftDeletes marks table as soft deleted through column, a nullable
+// timestamp. Select, Update and Delete builders created with strategy skip the
+// rows where it is set unless WithTrashed or OnlyTrashed is used.
+func RegisterSoftDeletes(strategy interfaces.QueryBuilderStrategy, table, column string) {
+	strategy.Scopes().RegisterSoftDeletes(table, column)
+}
+
+// WithTrashed includes the soft deleted rows.
+func (qb *SelectQueryBuilder) WithTrashed() *SelectQueryBuilder {
+	qb.builder.WithTrashed()
+	return qb
+}
+
+// OnlyTrashed selects only the soft deleted rows.
+func (qb *SelectQueryBuilder) OnlyTrashed() *SelectQueryBuilder {
+	qb.builder.OnlyTrashed()
+	return qb
+}
+
+// WithTrashed includes the soft deleted rows.
+func (ub *UpdateQueryBuilder) WithTrashed() *UpdateQueryBuilder {
+	ub.builder.WithTrashed()
+	return ub
+}
+
+// OnlyTrashed updates only the soft deleted rows.
+func (ub *UpdateQueryBuilder) OnlyTrashed() *UpdateQueryBuilder {
+	ub.builder.OnlyTrashed()
+	return ub
+}
+
+// WithTrashed includes the soft deleted rows.
+func (qb *DeleteQueryBuilder) WithTrashed() *DeleteQueryBuilder {
+	qb.builder.WithTrashed()
+	return qb
+}
+
+// OnlyTrashed deletes only the soft deleted rows.
+func (qb *DeleteQueryBuilder) OnlyTrashed() *DeleteQueryBuilder {
+	qb.builder.OnlyTrashed()
+	return qb
+}
+
+// SoftDelete builds an UPDATE setting the soft delete column of the rows that
+// are not deleted yet to the current time, instead of a DELETE.
+func (qb *DeleteQueryBuilder) SoftDelete() *DeleteQueryBuilder {
+	qb.builder.SoftDelete()
+	return qb
+}
+
+// ForceDelete builds a DELETE that also removes the soft deleted rows.
+func (qb *DeleteQueryBuilder) ForceDelete() *DeleteQueryBuilder {
+	qb.builder.ForceDelete()
+	return qb
+}
+
+// Restore builds an UPDATE clearing the soft delete column of the soft
+// deleted rows.
+func (qb *DeleteQueryBuilder) Restore() *DeleteQueryBuilder {
+	qb.builder.Restore()
+	return qb
+}
@@ -0,0 +1,14 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
+)
+
+// RegisterTimestamps enables the created and updated timestamps of table on
+// strategy. Insert builders fill both columns, including the rows of batches
+// and upserts; update builders fill the updated column. Values set by the
+// caller are kept.
+func RegisterTimestamps(strategy interfaces.QueryBuilderStrategy, table string, opts ...timestamp.Options) {
+	strategy.Timestamps().Register(table, opts...)
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// TxBeginner is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxOptions configures Transaction.
+type TxOptions struct {
+	Isolation sql.IsolationLevel
+	ReadOnly  bool
+	// MaxRetries is the number of times the whole closure is run again after a
+	// deadlock or serialization failure.
+	MaxRetries int
+	// Retryable decides which errors are retried. It defaults to
+	// IsRetryableError.
+	Retryable func(err error) bool
+}
+
+// Tx is a transaction handed to the closure of Transaction. It can be used as
+// the queryer of any builder and creates builders that run inside it.
+type Tx struct {
+	tx         *sql.Tx
+	strategy   interfaces.QueryBuilderStrategy
+	savepoints int
+}
+
+// Transaction runs fn in a transaction. The transaction is committed when fn
+// returns nil and rolled back when it returns an error or panics. With
+// MaxRetries set, fn is run again in a new transaction when it fails with a
+// retryable error.
+func Transaction(ctx context.Context, db TxBeginner, strategy interfaces.QueryBuilderStrategy, fn func(tx *Tx) error, opts ...TxOptions) error {
+	var o TxOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	retryable := o.Retryable
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := runTransaction(ctx, db, strategy, fn, &sql.TxOptions{Isolation: o.Isolation, ReadOnly: o.ReadOnly})
+		if err == nil || attempt >= o.MaxRetries || !retryable(err) || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func runTransaction(ctx context.Context, db TxBeginner, strategy interfaces.QueryBuilderStrategy, fn func(tx *Tx) error, opts *sql.TxOptions) (err error) {
+	sqlTx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = sqlTx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&Tx{tx: sqlTx, strategy: strategy}); err != nil {
+		if rerr := sqlTx.Rollback(); rerr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	return sqlTx.Commit()
+}
+
+// Transaction runs fn inside a savepoint. Only the work of fn is rolled back
+// when it returns an error or panics; the outer transaction continues.
+func (t *Tx) Transaction(ctx context.Context, fn func(tx *Tx) error) (err error) {
+	t.savepoints++
+	name := fmt.Sprintf("savepoint_%d", t.savepoints)
+
+	if _, err := t.tx.ExecContext(ctx, t.savepointSQL(name)); err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = t.tx.ExecContext(ctx, t.rollbackToSavepointSQL(name))
+			panic(p)
+		}
+	}()
+
+	if err := fn(t); err != nil {
+		if _, rerr := t.tx.ExecContext(ctx, t.rollbackToSavepointSQL(name)); rerr != nil {
+			return fmt.Errorf("%w (rollback to savepoint failed: %v)", err, rerr)
+		}
+		return err
+	}
+
+	// SQL Server has no RELEASE; the savepoint ends with the transaction.
+	if t.strategy.Dialect() == consts.DialectSQLServer {
+		return nil
+	}
+	_, err = t.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return err
+}
+
+func (t *Tx) savepointSQL(name string) string {
+	if t.strategy.Dialect() == consts.DialectSQLServer {
+		return "SAVE TRANSACTION " + name
+	}
+	return "SAVEPOINT " + name
+}
+
+func (t *Tx) rollbackToSavepointSQL(name string) string {
+	if t.strategy.Dialect() == consts.DialectSQLServer {
+		return "ROLLBACK TRANSACTION " + name
+	}
+	return "ROLLBACK TO SAVEPOINT " + name
+}
+
+// Tx returns the underlying *sql.Tx.
+func (t *Tx) Tx() *sql.Tx {
+	return t.tx
+}
+
+// Strategy returns the strategy the transaction was started with.
+func (t *Tx) Strategy() interfaces.QueryBuilderStrategy {
+	return t.strategy
+}
+
+func (t *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, args...)
+}
+
+func (t *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, args...)
+}
+
+// NewSelectQueryBuilder returns a select builder that runs in the transaction.
+func (t *Tx) NewSelectQueryBuilder() *SelectQueryBuilder {
+	return NewSelectQueryBuilder(t.strategy).SetQueryer(t)
+}
+
+// NewInsertQueryBuilder returns an insert builder that runs in the transaction.
+func (t *Tx) NewInsertQueryBuilder() *InsertQueryBuilder {
+	return NewInsertQueryBuilder(t.strategy).SetQueryer(t)
+}
+
+// NewUpdateQueryBuilder returns an update builder that runs in the transaction.
+func (t *Tx) NewUpdateQueryBuilder() *UpdateQueryBuilder {
+	return NewUpdateQueryBuilder(t.strategy).SetQueryer(t)
+}
+
+// NewDeleteQueryBuilder returns a delete builder that runs in the transaction.
+func (t *Tx) NewDeleteQueryBuilder() *DeleteQueryBuilder {
+	return NewDeleteQueryBuilder(t.strategy).SetQueryer(t)
+}
+
+// IsRetryableError reports whether err is a deadlock or serialization failure
+// worth retrying: MySQL errors 1213 and 1205, or SQLSTATE 40001 and 40P01.
+// Drivers are matched by the shape of their error types, so none of them is
+// imported.
+func IsRetryableError(err error) bool {
+	var state interface{ SQLState() string }
+	if errors.As(err, &state) && isRetryableSQLState(state.SQLState()) {
+		return true
+	}
+
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		v := reflect.Indirect(reflect.ValueOf(e))
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		// go-sql-driver/mysql
+		if f := v.FieldByName("Number"); f.IsValid() && f.CanUint() {
+			if n := f.Uint(); n == 1213 || n == 1205 {
+				return true
+			}
+		}
+		// lib/pq and pgconn
+		if f := v.FieldByName("Code"); f.IsValid() && f.Kind() == reflect.String && isRetryableSQLState(f.String()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isRetryableSQLState(state string) bool {
+	return state == "40001" || state == "40P01"
+}
+
+// warnLockOutsideTransaction logs a warning when a locking read runs outside a
+// transaction, where the lock is released as soon as the statement ends.
+func (qb *SelectQueryBuilder) warnLockOutsideTransaction() {
+	lock := qb.builder.GetLock()
+	if lock == nil || lock.LockType == "" {
+		return
+	}
+
+	switch qb.queryer.(type) {
+	case *Tx, *sql.Tx:
+		return
+	}
+	log.Printf("goquent: %s used outside a transaction; the lock is released when the statement ends", lock.LockType)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"github.com/faciam-dev/goquent-query-builder/executor"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 	"github.com/faciam-dev/goquent-query-builder/internal/query"
 )
@@ -10,12 +11,18 @@ type UpdateQueryBuilder struct {
 	JoinQueryBuilder[*UpdateQueryBuilder, query.UpdateBuilder]
 	OrderByQueryBuilder[*UpdateQueryBuilder, query.UpdateBuilder]
 	builder *query.UpdateBuilder
+	queryer executor.Queryer
 	QueryBuilderStrategy[UpdateQueryBuilder, query.UpdateBuilder]
 }
 
 func NewUpdateQueryBuilder(strategy interfaces.QueryBuilderStrategy) *UpdateQueryBuilder {
+	return newUpdateQueryBuilder(query.NewUpdateBuilder(strategy))
+}
+
+func newUpdateQueryBuilder(builder *query.UpdateBuilder) *UpdateQueryBuilder {
+	strategy := builder.GetStrategy()
 	ub := &UpdateQueryBuilder{
-		builder: query.NewUpdateBuilder(strategy),
+		builder: builder,
 	}
 
 	whereQueryBuilder := NewWhereQueryBuilder[*UpdateQueryBuilder, query.UpdateBuilder](strategy)
@@ -33,6 +40,14 @@ func NewUpdateQueryBuilder(strategy interfaces.QueryBuilderStrategy) *UpdateQuer
 	return ub
 }
 
+// Clone returns a copy of the builder with the same strategy and queryer that
+// can be extended and run independently of qb.
+func (qb *UpdateQueryBuilder) Clone() *UpdateQueryBuilder {
+	c := newUpdateQueryBuilder(qb.builder.Clone())
+	c.queryer = qb.queryer
+	return c
+}
+
 // Update
 func (ub *UpdateQueryBuilder) Update(data map[string]interface{}) *UpdateQueryBuilder {
 	ub.builder.Update(data)
@@ -40,12 +55,124 @@ func (ub *UpdateQueryBuilder) Update(data map[string]interface{}) *UpdateQueryBu
 	return ub
 }
 
+// From adds a table the update reads from besides its target. PostgreSQL,
+// SQLite and SQL Server list it in FROM, MySQL after the target. Relate it to
+// the target in the WHERE clause, for example with WhereColumn.
+func (ub *UpdateQueryBuilder) From(table string) *UpdateQueryBuilder {
+	ub.builder.From(table)
+	return ub
+}
+
+// FromSub adds a subquery, named alias, the update reads from besides its
+// target.
+func (ub *UpdateQueryBuilder) FromSub(sb *SelectQueryBuilder, alias string) *UpdateQueryBuilder {
+	ub.builder.FromSub(sb.builder, alias)
+	return ub
+}
+
+// Limit limits the number of rows updated. MySQL renders LIMIT and SQL Server
+// TOP. PostgreSQL and SQLite, and SQL Server with an order, match the rows in
+// a subquery by the column of LimitKey, which defaults to ctid and rowid.
+// Build returns ErrLimitNotSupported for a limited update of joined tables.
+func (ub *UpdateQueryBuilder) Limit(limit int64) *UpdateQueryBuilder {
+	ub.builder.Limit(limit)
+	return ub
+}
+
+// LimitKey sets the column, usually the primary key, matching the rows of a
+// Limit emulated with a subquery.
+func (ub *UpdateQueryBuilder) LimitKey(column string) *UpdateQueryBuilder {
+	ub.builder.LimitKey(column)
+	return ub
+}
+
+// Increment adds amount to column, rendered as column = column + amount. The
+// extra values are updated alongside it. A column of the form field->path
+// increments a number inside a JSON document.
+func (ub *UpdateQueryBuilder) Increment(column string, amount interface{}, extra ...map[string]interface{}) *UpdateQueryBuilder {
+	ub.builder.Increment(column, amount, extra...)
+	return ub
+}
+
+// Decrement subtracts amount from column, rendered as column = column - amount.
+// The extra values are updated alongside it.
+func (ub *UpdateQueryBuilder) Decrement(column string, amount interface{}, extra ...map[string]interface{}) *UpdateQueryBuilder {
+	ub.builder.Decrement(column, amount, extra...)
+	return ub
+}
+
+// IncrementEach adds each amount to its column.
+func (ub *UpdateQueryBuilder) IncrementEach(amounts map[string]interface{}) *UpdateQueryBuilder {
+	ub.builder.IncrementEach(amounts)
+	return ub
+}
+
+// UpdateStructOptions controls which fields UpdateStruct writes.
+type UpdateStructOptions = query.UpdateStructOptions
+
+// UpdateStruct sets the columns from a struct using its db tags. Fields tagged
+// pk become WHERE conditions and fields tagged readonly are skipped.
+func (ub *UpdateQueryBuilder) UpdateStruct(v any, opts ...UpdateStructOptions) *UpdateQueryBuilder {
+	var o UpdateStructOptions
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	ub.builder.UpdateStruct(v, o)
+	return ub
+}
+
 // Table
 func (ub *UpdateQueryBuilder) Table(table string) *UpdateQueryBuilder {
 	ub.builder.Table(table)
 	return ub
 }
 
+// Scope applies the named scopes registered for the table with
+// RegisterScope. It must be called after Table.
+func (ub *UpdateQueryBuilder) Scope(names ...string) *UpdateQueryBuilder {
+	ub.builder.Scope(names...)
+	return ub
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (ub *UpdateQueryBuilder) WithoutGlobalScope(names ...string) *UpdateQueryBuilder {
+	ub.builder.WithoutGlobalScope(names...)
+	return ub
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (ub *UpdateQueryBuilder) WithoutGlobalScopes() *UpdateQueryBuilder {
+	ub.builder.WithoutGlobalScopes()
+	return ub
+}
+
+// With adds a common table expression.
+func (ub *UpdateQueryBuilder) With(name string, sb *SelectQueryBuilder) *UpdateQueryBuilder {
+	ub.builder.With(name, sb.builder)
+	return ub
+}
+
+// WithRecursive adds a recursive common table expression whose anchor and
+// recursive parts are combined with UNION ALL.
+func (ub *UpdateQueryBuilder) WithRecursive(name string, columns []string, anchor *SelectQueryBuilder, recursive *SelectQueryBuilder) *UpdateQueryBuilder {
+	ub.builder.WithRecursive(name, columns, anchor.builder, recursive.builder)
+	return ub
+}
+
+// WithMaterialized adds a common table expression with the MATERIALIZED hint.
+func (ub *UpdateQueryBuilder) WithMaterialized(name string, sb *SelectQueryBuilder) *UpdateQueryBuilder {
+	ub.builder.WithMaterialized(name, sb.builder)
+	return ub
+}
+
+// Returning sets the columns returned for the updated rows. PostgreSQL and
+// SQLite use RETURNING, SQL Server uses OUTPUT and MySQL reports
+// ErrReturningNotSupported.
+func (ub *UpdateQueryBuilder) Returning(columns ...string) *UpdateQueryBuilder {
+	ub.builder.Returning(columns...)
+	return ub
+}
+
 // Build
 func (ub *UpdateQueryBuilder) Build() (string, []interface{}, error) {
 	return ub.builder.Build()
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/query"
+)
+
+type WindowBuilder struct {
+	builder *query.WindowBuilder
+}
+
+// Window bases the specification on a named window.
+func (wb *WindowBuilder) Window(name string) *WindowBuilder {
+	wb.builder.Window(name)
+	return wb
+}
+
+func (wb *WindowBuilder) PartitionBy(columns ...string) *WindowBuilder {
+	wb.builder.PartitionBy(columns...)
+	return wb
+}
+
+func (wb *WindowBuilder) OrderBy(column, ascDesc string) *WindowBuilder {
+	wb.builder.OrderBy(column, ascDesc)
+	return wb
+}
+
+func (wb *WindowBuilder) OrderByRaw(raw string) *WindowBuilder {
+	wb.builder.OrderByRaw(raw)
+	return wb
+}
+
+// RowsBetween sets a ROWS frame. Bounds are UNBOUNDED PRECEDING, CURRENT ROW,
+// UNBOUNDED FOLLOWING or "<n> PRECEDING" / "<n> FOLLOWING".
+func (wb *WindowBuilder) RowsBetween(start, end string) *WindowBuilder {
+	wb.builder.RowsBetween(start, end)
+	return wb
+}
+
+// RangeBetween sets a RANGE frame. It accepts the same bounds as RowsBetween.
+func (wb *WindowBuilder) RangeBetween(start, end string) *WindowBuilder {
+	wb.builder.RangeBetween(start, end)
+	return wb
+}
@@ -23,7 +23,10 @@ func NewMySQLQueryBuilder() *MySQLQueryBuilder {
 
 func newMySQLQueryBuilderWithUtil(u interfaces.SQLUtils) *MySQLQueryBuilder {
 	queryBuilder := &MySQLQueryBuilder{}
+	queryBuilder.BaseQueryBuilder = *base.NewBaseQueryBuilderWithUtil(u)
 	queryBuilder.util = u
+	queryBuilder.WithBaseBuilder = *base.NewWithBaseBuilder(u)
+	queryBuilder.WindowBaseBuilder = *base.NewWindowBaseBuilder(u)
 	queryBuilder.SelectBaseBuilder = *base.NewSelectBaseBuilder(u, &[]string{})
 	queryBuilder.JoinBaseBuilder = *base.NewJoinBaseBuilder(u, &structs.Joins{})
 	queryBuilder.FromBaseBuilder = *base.NewFromBaseBuilder(u)
@@ -33,12 +36,24 @@ func newMySQLQueryBuilderWithUtil(u interfaces.SQLUtils) *MySQLQueryBuilder {
 	queryBuilder.UpdateBaseBuilder = *base.NewUpdateBaseBuilder(u, &structs.UpdateQuery{})
 	queryBuilder.InsertBaseBuilder = *base.NewInsertBaseBuilder(u, &structs.InsertQuery{})
 	queryBuilder.DeleteBaseBuilder = *base.NewDeleteBaseBuilder(u, &structs.DeleteQuery{})
+	queryBuilder.SchemaBaseBuilder = *base.NewSchemaBaseBuilder(u)
 	return queryBuilder
 }
 
 func (MySQLQueryBuilder) ResetPlaceholderCounter() {
 }
 
+// ForBuild returns m; its placeholders are not numbered, so it keeps no state
+// while rendering.
+func (m *MySQLQueryBuilder) ForBuild() interfaces.QueryBuilderStrategy {
+	return m
+}
+
+// Dialect returns the name of the SQL dialect.
+func (m MySQLQueryBuilder) Dialect() string {
+	return m.util.Dialect()
+}
+
 func (m MySQLQueryBuilder) InsertIgnore(q *structs.InsertQuery) (string, []interface{}, error) {
 	return m.InsertBaseBuilder.InsertIgnore(q)
 }
@@ -49,6 +64,12 @@ func (m MySQLQueryBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}
 
 // Build builds the query.
 func (m MySQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
+	// WITH
+	withValues, err := m.With(sb, q, number, unions)
+	if err != nil {
+		return nil, err
+	}
+
 	// SELECT
 	*sb = append(*sb, "SELECT "...)
 	colValues, err := m.Select(sb, q.Columns, q.Table.Name, q.Joins)
@@ -58,7 +79,7 @@ func (m MySQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, union
 
 	*sb = append(*sb, " "...)
 	m.From(sb, q.Table.Name)
-	values := colValues
+	values := append(withValues, colValues...)
 
 	// JOIN
 	if q.Joins.JoinClauses != nil && (len(*q.Joins.JoinClauses) > 0 || len(*q.Joins.LateralJoins) > 0 || len(*q.Joins.Joins) > 0) {
@@ -81,6 +102,11 @@ func (m MySQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, union
 		values = append(values, groupByValues...)
 	}
 
+	// WINDOW
+	if err := m.Window(sb, q.Windows); err != nil {
+		return nil, err
+	}
+
 	// ORDER BY
 	if len(*q.Order) > 0 {
 		m.OrderBy(sb, q.Order)
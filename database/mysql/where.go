@@ -76,6 +76,14 @@ func (wb *WhereMySQLBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]inter
 				values = append(values, wb.ProcessJsonLength(sb, (wg)[i].Conditions[j])...)
 			case (wg)[i].Conditions[j].Function != "":
 				values = append(values, wb.whereBaseBuilder.ProcessFunction(sb, (wg)[i].Conditions[j])...)
+			case (wg)[i].Conditions[j].Keyset != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessKeyset(sb, (wg)[i].Conditions[j])...)
+			case (wg)[i].Conditions[j].Nested != nil:
+				nestedValues, err := wb.whereBaseBuilder.ProcessNested(sb, (wg)[i].Conditions[j], wb.Where)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, nestedValues...)
 			default:
 				rawValues, err := wb.whereBaseBuilder.ProcessRawCondition(sb, (wg)[i].Conditions[j])
 				if err != nil {
@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// ErrCopyExpression is returned when a row to copy holds a raw SQL expression,
+// which COPY cannot evaluate.
+var ErrCopyExpression = errors.New("raw expressions cannot be copied")
+
+// CopyFormat is the data format of a COPY statement.
+type CopyFormat int
+
+const (
+	// CopyText is the tab separated text format, the default of COPY.
+	CopyText CopyFormat = iota
+	// CopyCSV is the comma separated format with double quotes.
+	CopyCSV
+)
+
+// Copy is a COPY ... FROM STDIN statement and the columns, in order, of the
+// rows it reads.
+type Copy struct {
+	Query   string
+	Columns []string
+	Format  CopyFormat
+}
+
+// RowSource returns the next row to copy, or io.EOF after the last one.
+type RowSource func() (map[string]interface{}, error)
+
+// SliceRows returns a RowSource reading rows in order.
+func SliceRows(rows []map[string]interface{}) RowSource {
+	i := 0
+	return func() (map[string]interface{}, error) {
+		if i == len(rows) {
+			return nil, io.EOF
+		}
+		i++
+		return rows[i-1], nil
+	}
+}
+
+// CopyFrom builds the COPY statement loading columns of table from STDIN.
+func (m PostgreSQLQueryBuilder) CopyFrom(table string, columns []string, format CopyFormat) Copy {
+	sb := make([]byte, 0, 64)
+	sb = append(sb, "COPY "...)
+	sb = m.util.EscapeRelation(sb, table)
+	sb = append(sb, " ("...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = m.util.EscapeReference(sb, column)
+	}
+	sb = append(sb, ") FROM STDIN"...)
+	if format == CopyCSV {
+		sb = append(sb, " WITH (FORMAT csv)"...)
+	}
+
+	return Copy{
+		Query:   string(sb),
+		Columns: append([]string(nil), columns...),
+		Format:  format,
+	}
+}
+
+// CopyFromRows builds the COPY statement for rows as accepted by InsertBatch:
+// the columns of all rows, sorted, and a row missing a column loads NULL.
+func (m PostgreSQLQueryBuilder) CopyFromRows(table string, rows []map[string]interface{}, format CopyFormat) Copy {
+	set := make(map[string]struct{})
+	for _, row := range rows {
+		for column := range row {
+			set[column] = struct{}{}
+		}
+	}
+	columns := make([]string, 0, len(set))
+	for column := range set {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	return m.CopyFrom(table, columns, format)
+}
+
+// Reader returns the data of the rows of src in the format of c, encoded
+// while it is read. It can be passed to the copy API of a driver. Closing the
+// reader before its end stops reading src.
+func (c Copy) Reader(src RowSource) io.ReadCloser {
+	r, w := io.Pipe()
+	go func() {
+		enc := c.NewEncoder(w)
+		for {
+			row, err := src()
+			if err == io.EOF {
+				break
+			}
+			if err == nil {
+				err = enc.Encode(row)
+			}
+			if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+		}
+		w.Close()
+	}()
+	return r
+}
+
+// CopyEncoder writes rows in the format of a COPY statement.
+type CopyEncoder struct {
+	w       io.Writer
+	columns []string
+	format  CopyFormat
+	buf     []byte
+}
+
+// NewEncoder returns an encoder writing rows of the columns of c to w.
+func (c Copy) NewEncoder(w io.Writer) *CopyEncoder {
+	return &CopyEncoder{w: w, columns: c.Columns, format: c.Format}
+}
+
+// Encode writes the values of row for the columns of the statement. A
+// missing column is written as NULL.
+func (e *CopyEncoder) Encode(row map[string]interface{}) error {
+	values := make([]interface{}, len(e.columns))
+	for i, column := range e.columns {
+		values[i] = row[column]
+	}
+	return e.EncodeValues(values...)
+}
+
+// EncodeValues writes a row of values in the order of the columns of the
+// statement. Byte slices are written as bytea, times with their time zone,
+// and maps, slices and structs as JSON.
+func (e *CopyEncoder) EncodeValues(values ...interface{}) error {
+	if len(values) != len(e.columns) {
+		return fmt.Errorf("copy row has %d values for %d columns", len(values), len(e.columns))
+	}
+
+	e.buf = e.buf[:0]
+	for i, v := range values {
+		if i > 0 {
+			if e.format == CopyCSV {
+				e.buf = append(e.buf, ',')
+			} else {
+				e.buf = append(e.buf, '\t')
+			}
+		}
+
+		s, null, err := copyValue(v)
+		if err != nil {
+			return fmt.Errorf("copy column %q: %w", e.columns[i], err)
+		}
+		switch {
+		case e.format == CopyCSV && null:
+			// an unquoted empty field is NULL
+		case e.format == CopyCSV:
+			e.buf = appendCSVField(e.buf, s)
+		case null:
+			e.buf = append(e.buf, `\N`...)
+		default:
+			e.buf = appendTextField(e.buf, s)
+		}
+	}
+	e.buf = append(e.buf, '\n')
+
+	_, err := e.w.Write(e.buf)
+	return err
+}
+
+// copyValue returns v as the text PostgreSQL reads for it.
+func copyValue(v interface{}) (s string, null bool, err error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", true, nil
+		}
+		if v, err = valuer.Value(); err != nil {
+			return "", false, err
+		}
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "", true, nil
+	case string:
+		return v, false, nil
+	case []byte:
+		return `\x` + hex.EncodeToString(v), false, nil
+	case json.RawMessage:
+		return string(v), false, nil
+	case bool:
+		if v {
+			return "t", false, nil
+		}
+		return "f", false, nil
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05.999999Z07:00"), false, nil
+	case float32:
+		return formatFloat(float64(v), 32), false, nil
+	case float64:
+		return formatFloat(v, 64), false, nil
+	case structs.Expression:
+		return "", false, ErrCopyExpression
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return "", true, nil
+		}
+		return copyValue(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), false, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), false, nil
+	case reflect.Float32, reflect.Float64:
+		return formatFloat(rv.Float(), rv.Type().Bits()), false, nil
+	case reflect.Bool:
+		return copyValue(rv.Bool())
+	case reflect.String:
+		return rv.String(), false, nil
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false, err
+		}
+		return string(b), false, nil
+	}
+	return "", false, fmt.Errorf("cannot copy a value of type %T", v)
+}
+
+func formatFloat(f float64, bits int) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, bits)
+}
+
+// appendTextField appends s escaped for the text format.
+func appendTextField(buf []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\':
+			buf = append(buf, `\\`...)
+		case '\n':
+			buf = append(buf, `\n`...)
+		case '\r':
+			buf = append(buf, `\r`...)
+		case '\t':
+			buf = append(buf, `\t`...)
+		default:
+			buf = append(buf, c)
+		}
+	}
+	return buf
+}
+
+// appendCSVField appends s, quoted when it is empty, could be read as the end
+// of data marker or holds a delimiter, quote or line break.
+func appendCSVField(buf []byte, s string) []byte {
+	quote := s == "" || s == `\.`
+	for i := 0; i < len(s) && !quote; i++ {
+		switch s[i] {
+		case ',', '"', '\n', '\r':
+			quote = true
+		}
+	}
+	if !quote {
+		return append(buf, s...)
+	}
+
+	buf = append(buf, '"')
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' {
+			buf = append(buf, '"')
+		}
+		buf = append(buf, s[i])
+	}
+	return append(buf, '"')
+}
@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"sync"
+
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
@@ -23,13 +25,17 @@ func NewPostgreSQLQueryBuilder() *PostgreSQLQueryBuilder {
 
 func newPostgreSQLQueryBuilderWithUtil(u interfaces.SQLUtils) *PostgreSQLQueryBuilder {
 	queryBuilder := &PostgreSQLQueryBuilder{}
+	queryBuilder.BaseQueryBuilder = *base.NewBaseQueryBuilderWithUtil(u)
 	queryBuilder.util = u
+	queryBuilder.WithBaseBuilder = *base.NewWithBaseBuilder(u)
+	queryBuilder.WindowBaseBuilder = *base.NewWindowBaseBuilder(u)
 	queryBuilder.SelectBaseBuilder = *base.NewSelectBaseBuilder(u, &[]string{})
 	queryBuilder.JoinBaseBuilder = *base.NewJoinBaseBuilder(u, &structs.Joins{})
 	queryBuilder.FromBaseBuilder = *base.NewFromBaseBuilder(u)
 	queryBuilder.GroupByBaseBuilder = *base.NewGroupByBaseBuilder(u)
 	queryBuilder.OrderByBaseBuilder = *base.NewOrderByBaseBuilder(u, &[]structs.Order{})
 	queryBuilder.DeleteBaseBuilder = *base.NewDeleteBaseBuilder(u, &structs.DeleteQuery{})
+	queryBuilder.SchemaBaseBuilder = *base.NewSchemaBaseBuilder(u)
 	queryBuilder.InsertBaseBuilder = *base.NewInsertBaseBuilder(u, &structs.InsertQuery{})
 	queryBuilder.UpdateBaseBuilder = *base.NewUpdateBaseBuilder(u, &structs.UpdateQuery{})
 	queryBuilder.WherePostgreSQLBuilder = *NewWherePostgreSQLBuilder(u, []structs.WhereGroup{})
@@ -42,6 +48,18 @@ func (m PostgreSQLQueryBuilder) ResetPlaceholderCounter() {
 	}
 }
 
+// ForBuild returns a pooled strategy whose placeholders are numbered from 1.
+func (m *PostgreSQLQueryBuilder) ForBuild() interfaces.QueryBuilderStrategy {
+	s := buildStrategies.Get().(*buildStrategy)
+	s.utils.ResetPlaceholderCounter()
+	return s
+}
+
+// Dialect returns the name of the SQL dialect.
+func (m PostgreSQLQueryBuilder) Dialect() string {
+	return m.util.Dialect()
+}
+
 func (m PostgreSQLQueryBuilder) InsertIgnore(q *structs.InsertQuery) (string, []interface{}, error) {
 	return m.InsertBaseBuilder.InsertIgnore(q)
 }
@@ -52,6 +70,12 @@ func (m PostgreSQLQueryBuilder) Upsert(q *structs.InsertQuery) (string, []interf
 
 // Build builds the query.
 func (m PostgreSQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
+	// WITH
+	withValues, err := m.With(sb, q, number, unions)
+	if err != nil {
+		return nil, err
+	}
+
 	// SELECT
 	*sb = append(*sb, "SELECT "...)
 	colValues, err := m.Select(sb, q.Columns, q.Table.Name, q.Joins)
@@ -61,7 +85,7 @@ func (m PostgreSQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int,
 
 	*sb = append(*sb, " "...)
 	m.From(sb, q.Table.Name)
-	values := colValues
+	values := append(withValues, colValues...)
 
 	// JOIN
 	joinValues := m.Join(sb, q.Joins)
@@ -79,6 +103,11 @@ func (m PostgreSQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int,
 	groupByValues := m.GroupBy(sb, q.Group)
 	values = append(values, groupByValues...)
 
+	// WINDOW
+	if err := m.Window(sb, q.Windows); err != nil {
+		return nil, err
+	}
+
 	// ORDER BY
 	m.OrderBy(sb, q.Order)
 
@@ -103,3 +132,22 @@ func (m PostgreSQLQueryBuilder) Build(sb *[]byte, q *structs.Query, number int,
 func (m PostgreSQLQueryBuilder) Where(sb *[]byte, conditionGroups []structs.WhereGroup) ([]interface{}, error) {
 	return m.WherePostgreSQLBuilder.Where(sb, conditionGroups)
 }
+
+// buildStrategies pools the strategies returned by ForBuild.
+var buildStrategies = sync.Pool{
+	New: func() interface{} {
+		u := NewSQLUtils()
+		return &buildStrategy{PostgreSQLQueryBuilder: newPostgreSQLQueryBuilderWithUtil(u), utils: u}
+	},
+}
+
+// buildStrategy is a strategy rendering one statement at a time.
+type buildStrategy struct {
+	*PostgreSQLQueryBuilder
+	utils *SQLUtils
+}
+
+// Release returns the strategy to the pool.
+func (s *buildStrategy) Release() {
+	buildStrategies.Put(s)
+}
@@ -79,6 +79,14 @@ func (wb *WherePostgreSQLBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]
 				values = append(values, wb.ProcessJsonLength(sb, c)...)
 			case c.Function != "":
 				values = append(values, wb.whereBaseBuilder.ProcessFunction(sb, c)...)
+			case c.Keyset != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessKeyset(sb, c)...)
+			case c.Nested != nil:
+				nestedValues, err := wb.whereBaseBuilder.ProcessNested(sb, c, wb.Where)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, nestedValues...)
 			default:
 				rawValues, err := wb.whereBaseBuilder.ProcessRawCondition(sb, c)
 				if err != nil {
@@ -0,0 +1,133 @@
+package sqlite
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type SQLiteQueryBuilder struct {
+	base.BaseQueryBuilder
+	base.DeleteBaseBuilder
+	base.InsertBaseBuilder
+	base.UpdateBaseBuilder
+
+	WhereSQLiteBuilder
+
+	util interfaces.SQLUtils
+}
+
+func NewSQLiteQueryBuilder() *SQLiteQueryBuilder {
+	return newSQLiteQueryBuilderWithUtil(NewSQLUtils())
+}
+
+func newSQLiteQueryBuilderWithUtil(u interfaces.SQLUtils) *SQLiteQueryBuilder {
+	queryBuilder := &SQLiteQueryBuilder{}
+	queryBuilder.BaseQueryBuilder = *base.NewBaseQueryBuilderWithUtil(u)
+	queryBuilder.util = u
+	queryBuilder.WithBaseBuilder = *base.NewWithBaseBuilder(u)
+	queryBuilder.WindowBaseBuilder = *base.NewWindowBaseBuilder(u)
+	queryBuilder.SelectBaseBuilder = *base.NewSelectBaseBuilder(u, &[]string{})
+	queryBuilder.JoinBaseBuilder = *base.NewJoinBaseBuilder(u, &structs.Joins{})
+	queryBuilder.FromBaseBuilder = *base.NewFromBaseBuilder(u)
+	queryBuilder.GroupByBaseBuilder = *base.NewGroupByBaseBuilder(u)
+	queryBuilder.OrderByBaseBuilder = *base.NewOrderByBaseBuilder(u, &[]structs.Order{})
+	queryBuilder.DeleteBaseBuilder = *base.NewDeleteBaseBuilder(u, &structs.DeleteQuery{})
+	queryBuilder.SchemaBaseBuilder = *base.NewSchemaBaseBuilder(u)
+	queryBuilder.InsertBaseBuilder = *base.NewInsertBaseBuilder(u, &structs.InsertQuery{})
+	queryBuilder.UpdateBaseBuilder = *base.NewUpdateBaseBuilder(u, &structs.UpdateQuery{})
+	queryBuilder.WhereSQLiteBuilder = *NewWhereSQLiteBuilder(u, []structs.WhereGroup{})
+	return queryBuilder
+}
+
+func (SQLiteQueryBuilder) ResetPlaceholderCounter() {
+}
+
+// ForBuild returns m; its placeholders are not numbered, so it keeps no state
+// while rendering.
+func (m *SQLiteQueryBuilder) ForBuild() interfaces.QueryBuilderStrategy {
+	return m
+}
+
+// Dialect returns the name of the SQL dialect.
+func (m SQLiteQueryBuilder) Dialect() string {
+	return m.util.Dialect()
+}
+
+func (m SQLiteQueryBuilder) InsertIgnore(q *structs.InsertQuery) (string, []interface{}, error) {
+	return m.InsertBaseBuilder.InsertIgnore(q)
+}
+
+func (m SQLiteQueryBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}, error) {
+	return m.InsertBaseBuilder.Upsert(q)
+}
+
+// Lock is a no-op because SQLite locks the whole database file and has no
+// row level locking clauses.
+func (SQLiteQueryBuilder) Lock(sb *[]byte, lock *structs.Lock) {
+}
+
+// Build builds the query.
+func (m SQLiteQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
+	// WITH
+	withValues, err := m.With(sb, q, number, unions)
+	if err != nil {
+		return nil, err
+	}
+
+	// SELECT
+	*sb = append(*sb, "SELECT "...)
+	colValues, err := m.Select(sb, q.Columns, q.Table.Name, q.Joins)
+	if err != nil {
+		return nil, err
+	}
+
+	*sb = append(*sb, " "...)
+	m.From(sb, q.Table.Name)
+	values := append(withValues, colValues...)
+
+	// JOIN
+	joinValues := m.Join(sb, q.Joins)
+	values = append(values, joinValues...)
+
+	// WHERE
+	whereValues, err := m.Where(sb, q.ConditionGroups)
+	if err != nil {
+		return []interface{}{}, err
+	}
+	values = append(values, whereValues...)
+
+	// GROUP BY / HAVING
+	groupByValues := m.GroupBy(sb, q.Group)
+	values = append(values, groupByValues...)
+
+	// WINDOW
+	if err := m.Window(sb, q.Windows); err != nil {
+		return nil, err
+	}
+
+	// ORDER BY
+	m.OrderBy(sb, q.Order)
+
+	// LIMIT
+	// SQLite only accepts OFFSET after a LIMIT clause; -1 means no limit.
+	if q.Limit.Limit == 0 && q.Offset.Offset > 0 {
+		*sb = append(*sb, " LIMIT -1"...)
+	}
+	m.Limit(sb, q.Limit)
+
+	// OFFSET
+	m.Offset(sb, q.Offset)
+
+	// LOCK
+	m.Lock(sb, q.Lock)
+
+	// UNION
+	m.Union(sb, unions, number)
+
+	return values, nil
+}
+
+func (m SQLiteQueryBuilder) Where(sb *[]byte, conditionGroups []structs.WhereGroup) ([]interface{}, error) {
+	return m.WhereSQLiteBuilder.Where(sb, conditionGroups)
+}
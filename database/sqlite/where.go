@@ -0,0 +1,221 @@
+package sqlite
+
+import (
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/jsonutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type WhereSQLiteBuilder struct {
+	base.WhereBaseBuilder
+	whereBaseBuilder *base.WhereBaseBuilder
+	u                interfaces.SQLUtils
+}
+
+func NewWhereSQLiteBuilder(util interfaces.SQLUtils, wg []structs.WhereGroup) *WhereSQLiteBuilder {
+	return &WhereSQLiteBuilder{
+		whereBaseBuilder: base.NewWhereBaseBuilder(util, wg),
+		u:                util,
+	}
+}
+
+func (wb *WhereSQLiteBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]interface{}, error) {
+	if len(wg) == 0 {
+		return []interface{}{}, nil
+	}
+
+	// WHERE
+	if wb.whereBaseBuilder.HasCondition(wg) {
+		*sb = append(*sb, " WHERE "...)
+	}
+
+	values := make([]interface{}, 0)
+
+	for i, cg := range wg {
+		if len(cg.Conditions) == 0 {
+			continue
+		}
+
+		if i > 0 {
+			*sb = append(*sb, wb.WhereBaseBuilder.GetConditionGroupSeparator(cg, i)...)
+		}
+
+		*sb = append(*sb, wb.whereBaseBuilder.GetNotSeparator(cg)...)
+		*sb = append(*sb, wb.whereBaseBuilder.GetParenthesesOpen(cg)...)
+
+		for j, c := range cg.Conditions {
+			if j > 0 || (i > 0 && j == 0 && cg.IsDummyGroup) {
+				*sb = append(*sb, wb.whereBaseBuilder.GetConditionOperator(c)...)
+			}
+
+			switch {
+			case c.Query != nil:
+				subQueryValues, err := wb.whereBaseBuilder.ProcessSubQuery(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, subQueryValues...)
+			case c.Exists != nil:
+				existsValues, err := wb.whereBaseBuilder.ProcessExistsQuery(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, existsValues...)
+			case c.Between != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessBetweenCondition(sb, c)...)
+			case c.FullText != nil:
+				values = append(values, wb.ProcessFullText(sb, c)...)
+			case c.JsonContains != nil:
+				values = append(values, wb.ProcessJsonContains(sb, c)...)
+			case c.JsonLength != nil:
+				values = append(values, wb.ProcessJsonLength(sb, c)...)
+			case c.Function != "":
+				values = append(values, wb.ProcessFunction(sb, c)...)
+			case c.Keyset != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessKeyset(sb, c)...)
+			case c.Nested != nil:
+				nestedValues, err := wb.whereBaseBuilder.ProcessNested(sb, c, wb.Where)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, nestedValues...)
+			default:
+				rawValues, err := wb.whereBaseBuilder.ProcessRawCondition(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, rawValues...)
+			}
+		}
+		*sb = append(*sb, wb.whereBaseBuilder.GetParenthesesClose(cg)...)
+	}
+
+	return values, nil
+}
+
+// ProcessFullText renders MATCH conditions for FTS5 virtual tables. Each
+// column is matched separately and the results are combined with OR.
+func (wb *WhereSQLiteBuilder) ProcessFullText(sb *[]byte, c structs.Where) []interface{} {
+	values := make([]interface{}, 0, len(c.FullText.Columns))
+
+	if len(c.FullText.Columns) > 1 {
+		*sb = append(*sb, "("...)
+	}
+	for i, column := range c.FullText.Columns {
+		if i > 0 {
+			*sb = append(*sb, " OR "...)
+		}
+		*sb = wb.u.EscapeReference(*sb, column)
+		*sb = append(*sb, " MATCH "...)
+		*sb = append(*sb, wb.u.GetPlaceholder()...)
+		values = append(values, c.FullText.Search)
+	}
+	if len(c.FullText.Columns) > 1 {
+		*sb = append(*sb, ")"...)
+	}
+
+	return values
+}
+
+func (wb *WhereSQLiteBuilder) ProcessJsonContains(sb *[]byte, c structs.Where) []interface{} {
+	field, path := jsonutils.ParseJsonFieldAndPath(c.Column)
+
+	if len(c.JsonContains.Values) > 1 {
+		*sb = append(*sb, "("...)
+	}
+	for i := range c.JsonContains.Values {
+		if i > 0 {
+			*sb = append(*sb, " AND "...)
+		}
+		*sb = append(*sb, "EXISTS (SELECT 1 FROM json_each(json_extract("...)
+		*sb = wb.u.EscapeReference(*sb, field)
+		*sb = append(*sb, ", '$"...)
+		if len(path) > 0 {
+			*sb = append(*sb, "."+strings.Join(path, ".")...)
+		}
+		*sb = append(*sb, "')) WHERE json_each.value = "...)
+		*sb = append(*sb, wb.u.GetPlaceholder()...)
+		*sb = append(*sb, ")"...)
+	}
+	if len(c.JsonContains.Values) > 1 {
+		*sb = append(*sb, ")"...)
+	}
+
+	return c.JsonContains.Values
+}
+
+func (wb *WhereSQLiteBuilder) ProcessJsonLength(sb *[]byte, c structs.Where) []interface{} {
+	field, path := jsonutils.ParseJsonFieldAndPath(c.Column)
+	*sb = append(*sb, "json_array_length("...)
+	*sb = wb.u.EscapeReference(*sb, field)
+	if len(path) > 0 {
+		*sb = append(*sb, ", '$."+strings.Join(path, ".")+"')"...)
+	} else {
+		*sb = append(*sb, ")"...)
+	}
+	*sb = append(*sb, " "...)
+	*sb = append(*sb, c.JsonLength.Operator...)
+	*sb = append(*sb, " "...)
+	*sb = append(*sb, wb.u.GetPlaceholder()...)
+	return []interface{}{c.JsonLength.Value}
+}
+
+// ProcessFunction renders the date part conditions with the strftime family
+// because SQLite has no DATE/YEAR/MONTH/DAY/TIME functions of its own.
+func (wb *WhereSQLiteBuilder) ProcessFunction(sb *[]byte, c structs.Where) []interface{} {
+	switch c.Function {
+	case "DATE":
+		*sb = append(*sb, "date("...)
+		*sb = wb.u.EscapeReference(*sb, c.Column)
+		*sb = append(*sb, ")"...)
+	case "TIME":
+		*sb = append(*sb, "time("...)
+		*sb = wb.u.EscapeReference(*sb, c.Column)
+		*sb = append(*sb, ")"...)
+	case "YEAR":
+		wb.appendStrftime(sb, "%Y", c.Column)
+	case "MONTH":
+		wb.appendStrftime(sb, "%m", c.Column)
+	case "DAY":
+		wb.appendStrftime(sb, "%d", c.Column)
+	default:
+		return wb.whereBaseBuilder.ProcessFunction(sb, c)
+	}
+
+	*sb = append(*sb, " "...)
+	*sb = append(*sb, c.Condition...)
+	if c.ValueColumn != "" {
+		*sb = append(*sb, " "...)
+		*sb = wb.u.EscapeReference(*sb, c.ValueColumn)
+	} else if c.Value != nil {
+		if c.Condition == consts.Condition_IN || c.Condition == consts.Condition_NOT_IN || len(c.Value) > 1 {
+			*sb = append(*sb, " ("...)
+			for k := 0; k < len(c.Value); k++ {
+				if k > 0 {
+					*sb = append(*sb, ", "...)
+				}
+				*sb = append(*sb, wb.u.GetPlaceholder()...)
+			}
+			*sb = append(*sb, ")"...)
+		} else {
+			*sb = append(*sb, " "...)
+			*sb = append(*sb, wb.u.GetPlaceholder()...)
+		}
+	}
+
+	return c.Value
+}
+
+// appendStrftime casts the extracted part to INTEGER so that "1" and "01"
+// compare equal, matching the MySQL MONTH()/DAY()/YEAR() behaviour.
+func (wb *WhereSQLiteBuilder) appendStrftime(sb *[]byte, format string, column string) {
+	*sb = append(*sb, "CAST(strftime('"...)
+	*sb = append(*sb, format...)
+	*sb = append(*sb, "', "...)
+	*sb = wb.u.EscapeReference(*sb, column)
+	*sb = append(*sb, ") AS INTEGER)"...)
+}
@@ -0,0 +1,236 @@
+package sqlserver
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrInsertIgnoreUnsupported is returned because SQL Server has no INSERT
+// IGNORE equivalent. Use Upsert, which renders a MERGE statement, instead.
+var ErrInsertIgnoreUnsupported = errors.New("sqlserver: insert ignore is not supported, use upsert instead")
+
+type SQLServerQueryBuilder struct {
+	base.BaseQueryBuilder
+	base.DeleteBaseBuilder
+	base.InsertBaseBuilder
+	base.UpdateBaseBuilder
+
+	WhereSQLServerBuilder
+
+	util interfaces.SQLUtils
+}
+
+func NewSQLServerQueryBuilder() *SQLServerQueryBuilder {
+	return newSQLServerQueryBuilderWithUtil(NewSQLUtils())
+}
+
+func newSQLServerQueryBuilderWithUtil(u interfaces.SQLUtils) *SQLServerQueryBuilder {
+	queryBuilder := &SQLServerQueryBuilder{}
+	queryBuilder.BaseQueryBuilder = *base.NewBaseQueryBuilderWithUtil(u)
+	queryBuilder.util = u
+	queryBuilder.WithBaseBuilder = *base.NewWithBaseBuilder(u)
+	queryBuilder.WindowBaseBuilder = *base.NewWindowBaseBuilder(u)
+	queryBuilder.SelectBaseBuilder = *base.NewSelectBaseBuilder(u, &[]string{})
+	queryBuilder.JoinBaseBuilder = *base.NewJoinBaseBuilder(u, &structs.Joins{})
+	queryBuilder.FromBaseBuilder = *base.NewFromBaseBuilder(u)
+	queryBuilder.GroupByBaseBuilder = *base.NewGroupByBaseBuilder(u)
+	queryBuilder.OrderByBaseBuilder = *base.NewOrderByBaseBuilder(u, &[]structs.Order{})
+	queryBuilder.DeleteBaseBuilder = *base.NewDeleteBaseBuilder(u, &structs.DeleteQuery{})
+	queryBuilder.SchemaBaseBuilder = *base.NewSchemaBaseBuilder(u)
+	queryBuilder.InsertBaseBuilder = *base.NewInsertBaseBuilder(u, &structs.InsertQuery{})
+	queryBuilder.UpdateBaseBuilder = *base.NewUpdateBaseBuilder(u, &structs.UpdateQuery{})
+	queryBuilder.WhereSQLServerBuilder = *NewWhereSQLServerBuilder(u, []structs.WhereGroup{})
+	return queryBuilder
+}
+
+func (m SQLServerQueryBuilder) ResetPlaceholderCounter() {
+	if resetter, ok := m.util.(*SQLUtils); ok {
+		resetter.ResetPlaceholderCounter()
+	}
+}
+
+// ForBuild returns a pooled strategy whose placeholders are numbered from 1.
+func (m *SQLServerQueryBuilder) ForBuild() interfaces.QueryBuilderStrategy {
+	s := buildStrategies.Get().(*buildStrategy)
+	s.utils.ResetPlaceholderCounter()
+	return s
+}
+
+// Dialect returns the name of the SQL dialect.
+func (m SQLServerQueryBuilder) Dialect() string {
+	return m.util.Dialect()
+}
+
+func (m SQLServerQueryBuilder) InsertIgnore(q *structs.InsertQuery) (string, []interface{}, error) {
+	return "", nil, ErrInsertIgnoreUnsupported
+}
+
+// Upsert renders a MERGE statement.
+func (m SQLServerQueryBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}, error) {
+	return m.InsertBaseBuilder.Upsert(q)
+}
+
+// BuildInsert builds the INSERT query.
+func (m SQLServerQueryBuilder) BuildInsert(q *structs.InsertQuery) (string, []interface{}, error) {
+	if q.Ignore && q.Upsert == nil {
+		return m.InsertIgnore(q)
+	}
+
+	return m.InsertBaseBuilder.BuildInsert(q)
+}
+
+// Lock is a no-op because SQL Server expresses locks as table hints, which are
+// rendered right after the FROM clause by TableHint.
+func (SQLServerQueryBuilder) Lock(sb *[]byte, lock *structs.Lock) {
+}
+
+// TableHint renders the table hint for the given lock.
+func (SQLServerQueryBuilder) TableHint(sb *[]byte, lock *structs.Lock) {
+	if lock == nil {
+		return
+	}
+
+	switch lock.LockType {
+	case consts.Lock_FOR_UPDATE:
+		*sb = append(*sb, " WITH (UPDLOCK, ROWLOCK)"...)
+	case consts.Lock_SHARE_MODE:
+		*sb = append(*sb, " WITH (HOLDLOCK)"...)
+	}
+}
+
+// Limit renders nothing because the row limit is either a TOP clause or part
+// of the OFFSET ... FETCH clause. See Build.
+func (SQLServerQueryBuilder) Limit(sb *[]byte, limit structs.Limit) {
+}
+
+// Offset renders the OFFSET ... ROWS FETCH NEXT ... ROWS ONLY clause.
+func (SQLServerQueryBuilder) Offset(sb *[]byte, offset structs.Offset) {
+	if offset.Offset == 0 {
+		return
+	}
+
+	*sb = append(*sb, " OFFSET "...)
+	*sb = strconv.AppendInt(*sb, offset.Offset, 10)
+	*sb = append(*sb, " ROWS"...)
+}
+
+// Build builds the query.
+func (m SQLServerQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
+	// TOP is only used without an offset; OFFSET ... FETCH covers the rest.
+	top := q.Limit.Limit > 0 && q.Offset.Offset == 0
+
+	// WITH
+	withValues, err := m.With(sb, q, number, unions)
+	if err != nil {
+		return nil, err
+	}
+
+	// SELECT
+	*sb = append(*sb, "SELECT "...)
+	start := len(*sb)
+	colValues, err := m.Select(sb, q.Columns, q.Table.Name, q.Joins)
+	if err != nil {
+		return nil, err
+	}
+	if top {
+		m.insertTop(sb, start, q.Limit.Limit)
+	}
+
+	*sb = append(*sb, " "...)
+	m.From(sb, q.Table.Name)
+	values := append(withValues, colValues...)
+
+	// LOCK
+	m.TableHint(sb, q.Lock)
+
+	// JOIN
+	joinValues := m.Join(sb, q.Joins)
+	values = append(values, joinValues...)
+
+	// WHERE
+	whereValues, err := m.Where(sb, q.ConditionGroups)
+	if err != nil {
+		return []interface{}{}, err
+	}
+	values = append(values, whereValues...)
+
+	// GROUP BY / HAVING
+	groupByValues := m.GroupBy(sb, q.Group)
+	values = append(values, groupByValues...)
+
+	// WINDOW
+	if err := m.Window(sb, q.Windows); err != nil {
+		return nil, err
+	}
+
+	// ORDER BY
+	hasOrder := q.Order != nil && len(*q.Order) > 0
+	m.OrderBy(sb, q.Order)
+
+	// OFFSET / FETCH
+	if q.Offset.Offset > 0 {
+		// OFFSET requires an ORDER BY clause.
+		if !hasOrder {
+			*sb = append(*sb, " ORDER BY (SELECT NULL)"...)
+		}
+		m.Offset(sb, q.Offset)
+		if q.Limit.Limit > 0 {
+			*sb = append(*sb, " FETCH NEXT "...)
+			*sb = strconv.AppendInt(*sb, q.Limit.Limit, 10)
+			*sb = append(*sb, " ROWS ONLY"...)
+		}
+	}
+
+	// UNION
+	m.Union(sb, unions, number)
+
+	return values, nil
+}
+
+// insertTop places TOP (n) at the start of the select list, after DISTINCT
+// when present.
+func (m SQLServerQueryBuilder) insertTop(sb *[]byte, start int, limit int64) {
+	pos := start
+	const distinct = "DISTINCT "
+	if len(*sb)-start >= len(distinct) && string((*sb)[start:start+len(distinct)]) == distinct {
+		pos += len(distinct)
+	}
+
+	clause := make([]byte, 0, 16)
+	clause = append(clause, "TOP ("...)
+	clause = strconv.AppendInt(clause, limit, 10)
+	clause = append(clause, ") "...)
+
+	*sb = append(*sb, clause...)
+	copy((*sb)[pos+len(clause):], (*sb)[pos:len(*sb)-len(clause)])
+	copy((*sb)[pos:], clause)
+}
+
+func (m SQLServerQueryBuilder) Where(sb *[]byte, conditionGroups []structs.WhereGroup) ([]interface{}, error) {
+	return m.WhereSQLServerBuilder.Where(sb, conditionGroups)
+}
+
+// buildStrategies pools the strategies returned by ForBuild.
+var buildStrategies = sync.Pool{
+	New: func() interface{} {
+		u := NewSQLUtils()
+		return &buildStrategy{SQLServerQueryBuilder: newSQLServerQueryBuilderWithUtil(u), utils: u}
+	},
+}
+
+// buildStrategy is a strategy rendering one statement at a time.
+type buildStrategy struct {
+	*SQLServerQueryBuilder
+	utils *SQLUtils
+}
+
+// Release returns the strategy to the pool.
+func (s *buildStrategy) Release() {
+	buildStrategies.Put(s)
+}
@@ -0,0 +1,50 @@
+package sqlserver
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/sqlutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type SQLUtils struct {
+	placeholderNumber int
+}
+
+func NewSQLUtils() *SQLUtils {
+	return &SQLUtils{
+		placeholderNumber: 0,
+	}
+}
+
+func (s *SQLUtils) GetPlaceholder() string {
+	s.placeholderNumber++
+	phn := strconv.Itoa(s.placeholderNumber)
+	return strings.Join([]string{"@p", phn}, "")
+}
+
+func (s *SQLUtils) ResetPlaceholderCounter() {
+	s.placeholderNumber = 0
+}
+
+func (s *SQLUtils) EscapeRelation(sb []byte, value string) []byte {
+	return sqlutils.AppendEscapedRelationPair(sb, value, '[', ']')
+}
+
+func (s *SQLUtils) EscapeReference(sb []byte, value string) []byte {
+	return sqlutils.AppendEscapedReferencePair(sb, value, '[', ']')
+}
+
+func (s *SQLUtils) EscapeAliasedValue(sb []byte, value string) []byte {
+	return sqlutils.AppendEscapedAliasedValuePair(sb, value, '[', ']')
+}
+
+func (s *SQLUtils) GetQueryBuilderStrategy() interfaces.QueryBuilderStrategy {
+	return newSQLServerQueryBuilderWithUtil(s)
+}
+
+func (s *SQLUtils) Dialect() string {
+	return consts.DialectSQLServer
+}
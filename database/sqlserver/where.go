@@ -0,0 +1,204 @@
+package sqlserver
+
+import (
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/jsonutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type WhereSQLServerBuilder struct {
+	base.WhereBaseBuilder
+	whereBaseBuilder *base.WhereBaseBuilder
+	u                interfaces.SQLUtils
+}
+
+func NewWhereSQLServerBuilder(util interfaces.SQLUtils, wg []structs.WhereGroup) *WhereSQLServerBuilder {
+	return &WhereSQLServerBuilder{
+		whereBaseBuilder: base.NewWhereBaseBuilder(util, wg),
+		u:                util,
+	}
+}
+
+func (wb *WhereSQLServerBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]interface{}, error) {
+	if len(wg) == 0 {
+		return []interface{}{}, nil
+	}
+
+	// WHERE
+	if wb.whereBaseBuilder.HasCondition(wg) {
+		*sb = append(*sb, " WHERE "...)
+	}
+
+	values := make([]interface{}, 0)
+
+	for i, cg := range wg {
+		if len(cg.Conditions) == 0 {
+			continue
+		}
+
+		if i > 0 {
+			*sb = append(*sb, wb.WhereBaseBuilder.GetConditionGroupSeparator(cg, i)...)
+		}
+
+		*sb = append(*sb, wb.whereBaseBuilder.GetNotSeparator(cg)...)
+		*sb = append(*sb, wb.whereBaseBuilder.GetParenthesesOpen(cg)...)
+
+		for j, c := range cg.Conditions {
+			if j > 0 || (i > 0 && j == 0 && cg.IsDummyGroup) {
+				*sb = append(*sb, wb.whereBaseBuilder.GetConditionOperator(c)...)
+			}
+
+			switch {
+			case c.Query != nil:
+				subQueryValues, err := wb.whereBaseBuilder.ProcessSubQuery(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, subQueryValues...)
+			case c.Exists != nil:
+				existsValues, err := wb.whereBaseBuilder.ProcessExistsQuery(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, existsValues...)
+			case c.Between != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessBetweenCondition(sb, c)...)
+			case c.FullText != nil:
+				values = append(values, wb.ProcessFullText(sb, c)...)
+			case c.JsonContains != nil:
+				values = append(values, wb.ProcessJsonContains(sb, c)...)
+			case c.JsonLength != nil:
+				values = append(values, wb.ProcessJsonLength(sb, c)...)
+			case c.Function != "":
+				values = append(values, wb.ProcessFunction(sb, c)...)
+			case c.Keyset != nil:
+				values = append(values, wb.whereBaseBuilder.ProcessKeyset(sb, c)...)
+			case c.Nested != nil:
+				nestedValues, err := wb.whereBaseBuilder.ProcessNested(sb, c, wb.Where)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, nestedValues...)
+			default:
+				rawValues, err := wb.whereBaseBuilder.ProcessRawCondition(sb, c)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, rawValues...)
+			}
+		}
+		*sb = append(*sb, wb.whereBaseBuilder.GetParenthesesClose(cg)...)
+	}
+
+	return values, nil
+}
+
+// ProcessFullText renders FREETEXT by default and CONTAINS when the "boolean"
+// mode is requested. Both require a full-text index on the columns.
+func (wb *WhereSQLServerBuilder) ProcessFullText(sb *[]byte, c structs.Where) []interface{} {
+	predicate := "FREETEXT"
+	if c.FullText.Options != nil {
+		if mode, ok := c.FullText.Options["mode"]; ok && mode.(string) == "boolean" {
+			predicate = "CONTAINS"
+		}
+	}
+
+	*sb = append(*sb, predicate...)
+	*sb = append(*sb, "(("...)
+	for i, column := range c.FullText.Columns {
+		if i > 0 {
+			*sb = append(*sb, ", "...)
+		}
+		*sb = wb.u.EscapeReference(*sb, column)
+	}
+	*sb = append(*sb, "), "...)
+	*sb = append(*sb, wb.u.GetPlaceholder()...)
+	*sb = append(*sb, ")"...)
+
+	return []interface{}{c.FullText.Search}
+}
+
+func (wb *WhereSQLServerBuilder) ProcessJsonContains(sb *[]byte, c structs.Where) []interface{} {
+	field, path := jsonutils.ParseJsonFieldAndPath(c.Column)
+
+	if len(c.JsonContains.Values) > 1 {
+		*sb = append(*sb, "("...)
+	}
+	for i := range c.JsonContains.Values {
+		if i > 0 {
+			*sb = append(*sb, " AND "...)
+		}
+		*sb = append(*sb, "EXISTS (SELECT 1 FROM "...)
+		wb.appendOpenJSON(sb, field, path)
+		*sb = append(*sb, " WHERE [value] = "...)
+		*sb = append(*sb, wb.u.GetPlaceholder()...)
+		*sb = append(*sb, ")"...)
+	}
+	if len(c.JsonContains.Values) > 1 {
+		*sb = append(*sb, ")"...)
+	}
+
+	return c.JsonContains.Values
+}
+
+func (wb *WhereSQLServerBuilder) ProcessJsonLength(sb *[]byte, c structs.Where) []interface{} {
+	field, path := jsonutils.ParseJsonFieldAndPath(c.Column)
+	*sb = append(*sb, "(SELECT COUNT(*) FROM "...)
+	wb.appendOpenJSON(sb, field, path)
+	*sb = append(*sb, ") "...)
+	*sb = append(*sb, c.JsonLength.Operator...)
+	*sb = append(*sb, " "...)
+	*sb = append(*sb, wb.u.GetPlaceholder()...)
+	return []interface{}{c.JsonLength.Value}
+}
+
+// ProcessFunction renders DATE and TIME as casts because SQL Server has no
+// functions of that name. YEAR, MONTH and DAY exist natively.
+func (wb *WhereSQLServerBuilder) ProcessFunction(sb *[]byte, c structs.Where) []interface{} {
+	switch c.Function {
+	case "DATE", "TIME":
+		*sb = append(*sb, "CAST("...)
+		*sb = wb.u.EscapeReference(*sb, c.Column)
+		*sb = append(*sb, " AS "...)
+		*sb = append(*sb, c.Function...)
+		*sb = append(*sb, ")"...)
+	default:
+		return wb.whereBaseBuilder.ProcessFunction(sb, c)
+	}
+
+	*sb = append(*sb, " "...)
+	*sb = append(*sb, c.Condition...)
+	if c.ValueColumn != "" {
+		*sb = append(*sb, " "...)
+		*sb = wb.u.EscapeReference(*sb, c.ValueColumn)
+	} else if c.Value != nil {
+		if c.Condition == consts.Condition_IN || c.Condition == consts.Condition_NOT_IN || len(c.Value) > 1 {
+			*sb = append(*sb, " ("...)
+			for k := 0; k < len(c.Value); k++ {
+				if k > 0 {
+					*sb = append(*sb, ", "...)
+				}
+				*sb = append(*sb, wb.u.GetPlaceholder()...)
+			}
+			*sb = append(*sb, ")"...)
+		} else {
+			*sb = append(*sb, " "...)
+			*sb = append(*sb, wb.u.GetPlaceholder()...)
+		}
+	}
+
+	return c.Value
+}
+
+func (wb *WhereSQLServerBuilder) appendOpenJSON(sb *[]byte, field string, path []string) {
+	*sb = append(*sb, "OPENJSON("...)
+	*sb = wb.u.EscapeReference(*sb, field)
+	if len(path) > 0 {
+		*sb = append(*sb, ", '$."+strings.Join(path, ".")+"'"...)
+	}
+	*sb = append(*sb, ")"...)
+}
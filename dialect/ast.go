@@ -0,0 +1,48 @@
+package dialect
+
+import "github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+
+// Query structures built by the api package and rendered by a
+// QueryBuilderStrategy.
+type (
+	Query           = structs.Query
+	SelectQuery     = structs.SelectQuery
+	InsertQuery     = structs.InsertQuery
+	UpdateQuery     = structs.UpdateQuery
+	DeleteQuery     = structs.DeleteQuery
+	SchemaQuery     = structs.SchemaQuery
+	Column          = structs.Column
+	WindowFunction  = structs.WindowFunction
+	WindowSpec      = structs.WindowSpec
+	WindowFrame     = structs.WindowFrame
+	NamedWindow     = structs.NamedWindow
+	Table           = structs.Table
+	Where           = structs.Where
+	Keyset          = structs.Keyset
+	WhereBetween    = structs.WhereBetween
+	Exists          = structs.Exists
+	FullText        = structs.FullText
+	FullTextOptions = structs.FullTextOptions
+	JsonContains    = structs.JsonContains
+	JsonLength      = structs.JsonLength
+	WhereGroup      = structs.WhereGroup
+	CTE             = structs.CTE
+	Union           = structs.Union
+	Upsert          = structs.Upsert
+	On              = structs.On
+	JoinClause      = structs.JoinClause
+	Join            = structs.Join
+	Joins           = structs.Joins
+	Limit           = structs.Limit
+	Offset          = structs.Offset
+	Order           = structs.Order
+	Orders          = structs.Orders
+	GroupBy         = structs.GroupBy
+	Having          = structs.Having
+	Lock            = structs.Lock
+	SchemaTable     = structs.SchemaTable
+	SchemaColumn    = structs.SchemaColumn
+	SchemaIndex     = structs.SchemaIndex
+	ForeignKey      = structs.ForeignKey
+	RenameColumn    = structs.RenameColumn
+)
@@ -0,0 +1,97 @@
+package dialect
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/db/base"
+)
+
+// Base renderers a dialect is composed of. BaseQueryBuilder embeds all of
+// them and implements QueryBuilderStrategy with the SQL of the base dialect.
+type (
+	BaseQueryBuilder   = base.BaseQueryBuilder
+	WithBaseBuilder    = base.WithBaseBuilder
+	WindowBaseBuilder  = base.WindowBaseBuilder
+	UnionBaseBuilder   = base.UnionBaseBuilder
+	SelectBaseBuilder  = base.SelectBaseBuilder
+	FromBaseBuilder    = base.FromBaseBuilder
+	WhereBaseBuilder   = base.WhereBaseBuilder
+	JoinBaseBuilder    = base.JoinBaseBuilder
+	OrderByBaseBuilder = base.OrderByBaseBuilder
+	GroupByBaseBuilder = base.GroupByBaseBuilder
+	LimitBaseBuilder   = base.LimitBaseBuilder
+	OffsetBaseBuilder  = base.OffsetBaseBuilder
+	InsertBaseBuilder  = base.InsertBaseBuilder
+	UpdateBaseBuilder  = base.UpdateBaseBuilder
+	DeleteBaseBuilder  = base.DeleteBaseBuilder
+	SchemaBaseBuilder  = base.SchemaBaseBuilder
+)
+
+// Errors returned by the base renderers.
+var (
+	ErrReturningNotSupported = base.ErrReturningNotSupported
+	ErrSchemaUnsupported     = base.ErrSchemaUnsupported
+)
+
+// NewBaseQueryBuilder returns a BaseQueryBuilder whose renderers all use u.
+func NewBaseQueryBuilder(u SQLUtils) *BaseQueryBuilder {
+	return base.NewBaseQueryBuilderWithUtil(u)
+}
+
+func NewWithBaseBuilder(u SQLUtils) *WithBaseBuilder {
+	return base.NewWithBaseBuilder(u)
+}
+
+func NewWindowBaseBuilder(u SQLUtils) *WindowBaseBuilder {
+	return base.NewWindowBaseBuilder(u)
+}
+
+func NewUnionBaseBuilder() *UnionBaseBuilder {
+	return base.NewUnionBaseBuilder()
+}
+
+func NewSelectBaseBuilder(u SQLUtils, columnNames *[]string) *SelectBaseBuilder {
+	return base.NewSelectBaseBuilder(u, columnNames)
+}
+
+func NewFromBaseBuilder(u SQLUtils) *FromBaseBuilder {
+	return base.NewFromBaseBuilder(u)
+}
+
+func NewWhereBaseBuilder(u SQLUtils, wg []WhereGroup) *WhereBaseBuilder {
+	return base.NewWhereBaseBuilder(u, wg)
+}
+
+func NewJoinBaseBuilder(u SQLUtils, j *Joins) *JoinBaseBuilder {
+	return base.NewJoinBaseBuilder(u, j)
+}
+
+func NewOrderByBaseBuilder(u SQLUtils, order *[]Order) *OrderByBaseBuilder {
+	return base.NewOrderByBaseBuilder(u, order)
+}
+
+func NewGroupByBaseBuilder(u SQLUtils) *GroupByBaseBuilder {
+	return base.NewGroupByBaseBuilder(u)
+}
+
+func NewLimitBaseBuilder() *LimitBaseBuilder {
+	return base.NewLimitBaseBuilder()
+}
+
+func NewOffsetBaseBuilder() *OffsetBaseBuilder {
+	return base.NewOffsetBaseBuilder()
+}
+
+func NewInsertBaseBuilder(u SQLUtils, iq *InsertQuery) *InsertBaseBuilder {
+	return base.NewInsertBaseBuilder(u, iq)
+}
+
+func NewUpdateBaseBuilder(u SQLUtils, uq *UpdateQuery) *UpdateBaseBuilder {
+	return base.NewUpdateBaseBuilder(u, uq)
+}
+
+func NewDeleteBaseBuilder(u SQLUtils, dq *DeleteQuery) *DeleteBaseBuilder {
+	return base.NewDeleteBaseBuilder(u, dq)
+}
+
+func NewSchemaBaseBuilder(u SQLUtils) *SchemaBaseBuilder {
+	return base.NewSchemaBaseBuilder(u)
+}
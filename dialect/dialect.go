@@ -0,0 +1,76 @@
+// Package dialect is the extension API for SQL dialects. It exposes the
+// strategy interface implemented by the packages under database, the query
+// structures they render and the base renderers they are composed of, so a
+// dialect can be written outside this module the way database/mysql is.
+//
+// A dialect implements SQLUtils for its quoting and placeholders, embeds
+// BaseQueryBuilder and overrides the methods whose SQL differs:
+//
+//	type TiDBQueryBuilder struct {
+//		dialect.BaseQueryBuilder
+//	}
+//
+//	func NewTiDBQueryBuilder() *TiDBQueryBuilder {
+//		return &TiDBQueryBuilder{BaseQueryBuilder: *dialect.NewBaseQueryBuilder(&TiDBUtils{})}
+//	}
+//
+// Strategies are shared between goroutines. The builders call ForBuild once
+// per statement; a dialect whose SQLUtils numbers its placeholders overrides
+// it to return a strategy with its own SQLUtils, taken from a pool and given
+// back by Releaser, as database/postgres does.
+//
+// The types are aliases of the ones used internally, so values can be passed
+// to the api package without conversion.
+package dialect
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/sqlutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// QueryBuilderStrategy renders the query structures of a dialect. It is the
+// type accepted by the builders of the api package.
+type QueryBuilderStrategy = interfaces.QueryBuilderStrategy
+
+// SQLUtils quotes identifiers and returns the placeholder of a dialect. The
+// base renderers call it for everything that is not plain SQL.
+type SQLUtils = interfaces.SQLUtils
+
+// Releaser is implemented by the strategies ForBuild takes from a pool.
+type Releaser = interfaces.Releaser
+
+// Names of the built-in dialects, as returned by QueryBuilderStrategy.Dialect.
+// The base renderers switch on these names; any other name gets the generic
+// SQL of the base dialect.
+const (
+	Base       = consts.DialectBase
+	MySQL      = consts.DialectMySQL
+	PostgreSQL = consts.DialectPostgreSQL
+	SQLite     = consts.DialectSQLite
+	SQLServer  = consts.DialectSQLServer
+)
+
+// Logical operators of Where and WhereGroup.
+const (
+	And = consts.LogicalOperator_AND
+	Or  = consts.LogicalOperator_OR
+)
+
+// AppendEscapedRelation appends a table name, optionally qualified by a schema
+// and followed by an alias, quoting each part with quote.
+func AppendEscapedRelation(sb []byte, value string, quote byte) []byte {
+	return sqlutils.AppendEscapedRelation(sb, value, quote)
+}
+
+// AppendEscapedReference appends a column reference such as "users.id",
+// quoting each part with quote.
+func AppendEscapedReference(sb []byte, value string, quote byte) []byte {
+	return sqlutils.AppendEscapedReference(sb, value, quote)
+}
+
+// AppendEscapedAliasedValue appends a column reference followed by an optional
+// alias, quoting each part with quote.
+func AppendEscapedAliasedValue(sb []byte, value string, quote byte) []byte {
+	return sqlutils.AppendEscapedAliasedValue(sb, value, quote)
+}
@@ -0,0 +1,72 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+// ErrUnknownDialect is returned by New for a name that was not registered.
+var ErrUnknownDialect = errors.New("dialect: unknown dialect")
+
+// Factory returns a new strategy of a dialect.
+type Factory func() QueryBuilderStrategy
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+func init() {
+	Register(MySQL, func() QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() })
+	Register(PostgreSQL, func() QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() })
+	Register(SQLite, func() QueryBuilderStrategy { return sqlite.NewSQLiteQueryBuilder() })
+	Register(SQLServer, func() QueryBuilderStrategy { return sqlserver.NewSQLServerQueryBuilder() })
+}
+
+// Register makes a dialect available by name, typically from the init function
+// of the package implementing it. It panics if factory is nil or the name is
+// already registered.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if factory == nil {
+		panic("dialect: Register factory is nil")
+	}
+	if _, dup := factories[name]; dup {
+		panic("dialect: Register called twice for dialect " + name)
+	}
+	factories[name] = factory
+}
+
+// New returns a new strategy of the dialect registered as name.
+func New(name string) (QueryBuilderStrategy, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownDialect, name)
+	}
+	return factory(), nil
+}
+
+// Names returns the sorted names of the registered dialects.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -1,79 +1,96 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/faciam-dev/goquent-query-builder/api"
 	"github.com/faciam-dev/goquent-query-builder/database/mysql"
-	"github.com/faciam-dev/goquent-query-builder/internal/profiling"
+	"github.com/faciam-dev/goquent-query-builder/hook"
 )
 
 func main() {
+	ctx := context.Background()
+
+	// Open a database which only prints the statements it is given
+	db, err := sql.Open("simulated", "")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
 	// Initialize database strategy
 	dbStrategy := mysql.NewMySQLQueryBuilder()
 
+	// Time every statement run by the builders
+	dbStrategy.Use(hook.Funcs{
+		Around: func(ctx context.Context, e *hook.Event, next func(ctx context.Context) error) error {
+			start := time.Now()
+			err := next(ctx)
+			log.Printf("Query executed in %s", time.Since(start))
+			return err
+		},
+	})
+
 	// Executing query: SELECT `id`, `users`.`name` as `name` FROM `users` INNER JOIN `profiles` ON `users`.`id` = `profiles`.`user_id` WHERE `profiles`.`age` > ? ORDER BY `users`.`name` ASC with values: [18]
-	qb := api.NewSelectQueryBuilder(dbStrategy).
+	_, err = api.NewSelectQueryBuilder(dbStrategy).
+		SetQueryer(db).
 		Table("users").
 		Select("id", "users.name as name").
 		Join("profiles", "users.id", "=", "profiles.user_id").
 		Where("profiles.age", ">", 18).
-		OrderBy("users.name", "ASC")
-
-	query, values, err := qb.Build()
+		OrderBy("users.name", "ASC").
+		Get(ctx)
 
 	if err != nil {
 		fmt.Println("Error:", err)
 		return
 	}
 
-	profiling.Profile(query, func() {
-		fmt.Println("Executing query:", query, "with values:", values)
-		time.Sleep(2 * time.Second) // Simulate query execution
-	})
-
 	// INSERT INTO users (age, name) VALUES (?, ?)
-	iqb := api.NewInsertQueryBuilder(dbStrategy).
+	_, err = api.NewInsertQueryBuilder(dbStrategy).
+		SetQueryer(db).
 		Table("users").
 		Insert(map[string]interface{}{
 			"name": "John Doe",
 			"age":  30,
-		})
-
-	query, values, _ = iqb.Build()
+		}).
+		Exec(ctx)
 
-	profiling.Profile(query, func() {
-		fmt.Println("Executing query:", query, "with values:", values)
-		time.Sleep(2 * time.Second) // Simulate query execution
-	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
 
 	// UPDATE users SET age = ? WHERE id = ?
-	uqb := api.NewUpdateQueryBuilder(dbStrategy).
+	_, err = api.NewUpdateQueryBuilder(dbStrategy).
+		SetQueryer(db).
 		Table("users").
 		Update(map[string]interface{}{
 			"age": 40,
 		}).
-		Where("id", "=", 1)
-
-	query, values, _ = uqb.Build()
+		Where("id", "=", 1).
+		Exec(ctx)
 
-	profiling.Profile(query, func() {
-		fmt.Println("Executing query:", query, "with values:", values)
-		time.Sleep(2 * time.Second) // Simulate query execution
-	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
 
 	// DELETE FROM users WHERE id = ?
-	dqb := api.NewDeleteQueryBuilder(dbStrategy).
+	_, err = api.NewDeleteQueryBuilder(dbStrategy).
+		SetQueryer(db).
 		Table("users").
 		Where("id", "=", 1).
-		Delete()
-
-	query, values, _ = dqb.Build()
+		Delete().
+		Exec(ctx)
 
-	profiling.Profile(query, func() {
-		fmt.Println("Executing query:", query, "with values:", values)
-		time.Sleep(2 * time.Second) // Simulate query execution
-	})
+	if err != nil {
+		fmt.Println("Error:", err)
+		return
+	}
 
 }
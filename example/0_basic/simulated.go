@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// simulatedDriver is a database/sql driver which prints the statements it is
+// given and pretends to run them, so the example needs no server.
+type simulatedDriver struct{}
+
+func init() {
+	sql.Register("simulated", simulatedDriver{})
+}
+
+func (simulatedDriver) Open(string) (driver.Conn, error) {
+	return simulatedConn{}, nil
+}
+
+type simulatedConn struct{}
+
+func (simulatedConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("simulated: prepared statements are not supported")
+}
+
+func (simulatedConn) Close() error {
+	return nil
+}
+
+func (simulatedConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("simulated: transactions are not supported")
+}
+
+func (simulatedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	simulate(query, args)
+	return simulatedRows{}, nil
+}
+
+func (simulatedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	simulate(query, args)
+	return driver.RowsAffected(1), nil
+}
+
+// simulate prints the statement and waits as if it were run.
+func simulate(query string, args []driver.NamedValue) {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	fmt.Println("Executing query:", query, "with values:", values)
+	time.Sleep(2 * time.Second) // Simulate query execution
+}
+
+// simulatedRows is an empty result.
+type simulatedRows struct{}
+
+func (simulatedRows) Columns() []string {
+	return nil
+}
+
+func (simulatedRows) Close() error {
+	return nil
+}
+
+func (simulatedRows) Next([]driver.Value) error {
+	return io.EOF
+}
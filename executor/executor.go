@@ -0,0 +1,175 @@
+// Package executor runs queries built by the api package through database/sql.
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Queryer is the part of *sql.DB, *sql.Tx and *sql.Conn used to run queries.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// ErrNoQueryer is returned when a terminal method is called on a builder
+// without a Queryer.
+var ErrNoQueryer = errors.New("executor: no queryer set")
+
+// Result is the outcome of an INSERT, UPDATE or DELETE statement.
+type Result struct {
+	RowsAffected int64
+	// LastInsertID is zero when the driver does not report it, e.g. PostgreSQL.
+	LastInsertID int64
+}
+
+// Query runs the query and returns every row as a map keyed by column name.
+// []byte values are returned as strings.
+func Query(ctx context.Context, q Queryer, query string, args ...interface{}) ([]map[string]interface{}, error) {
+	if q == nil {
+		return nil, ErrNoQueryer
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]map[string]interface{}, 0)
+	for rows.Next() {
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			row[column] = values[i]
+		}
+		results = append(results, row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// QueryColumn runs the query and returns the values of the first column.
+func QueryColumn(ctx context.Context, q Queryer, query string, args ...interface{}) ([]interface{}, error) {
+	if q == nil {
+		return nil, ErrNoQueryer
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("executor: query returned no columns")
+	}
+
+	results := make([]interface{}, 0)
+	for rows.Next() {
+		values, err := scanRow(rows, len(columns))
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, values[0])
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// Exec runs a statement that returns no rows.
+func Exec(ctx context.Context, q Queryer, query string, args ...interface{}) (Result, error) {
+	if q == nil {
+		return Result{}, ErrNoQueryer
+	}
+
+	res, err := q.ExecContext(ctx, query, args...)
+	if err != nil {
+		return Result{}, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return Result{}, err
+	}
+
+	// not every driver supports LastInsertId, so its error is not fatal.
+	lastID, err := res.LastInsertId()
+	if err != nil {
+		lastID = 0
+	}
+
+	return Result{RowsAffected: affected, LastInsertID: lastID}, nil
+}
+
+// ToInt64 converts a scanned numeric value to int64. Drivers differ in the
+// type they use for COUNT(*) and similar results.
+func ToInt64(v interface{}) (int64, error) {
+	switch n := v.(type) {
+	case int64:
+		return n, nil
+	case int:
+		return int64(n), nil
+	case int32:
+		return int64(n), nil
+	case uint64:
+		return int64(n), nil
+	case float64:
+		return int64(n), nil
+	case bool:
+		if n {
+			return 1, nil
+		}
+		return 0, nil
+	case string:
+		return strconv.ParseInt(n, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(n), 10, 64)
+	default:
+		return 0, fmt.Errorf("executor: cannot convert %T to int64", v)
+	}
+}
+
+func scanRow(rows *sql.Rows, n int) ([]interface{}, error) {
+	values := make([]interface{}, n)
+	ptrs := make([]interface{}, n)
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+
+	if err := rows.Scan(ptrs...); err != nil {
+		return nil, err
+	}
+
+	for i, v := range values {
+		if b, ok := v.([]byte); ok {
+			values[i] = string(b)
+		}
+	}
+
+	return values, nil
+}
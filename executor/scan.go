@@ -0,0 +1,87 @@
+package executor
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structutils"
+)
+
+// ScanStructs reads every row into dest, which must be a pointer to a slice of
+// structs or of struct pointers. Columns are matched against the db tags; a
+// qualified column such as "users.name" also matches "name". Columns without a
+// matching field are discarded.
+func ScanStructs(rows *sql.Rows, dest any) error {
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() || dv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("executor: dest must be a pointer to a slice, got %T", dest)
+	}
+	slice := dv.Elem()
+
+	elemType := slice.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Pointer
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+
+	s, err := structutils.TypeOf(structType)
+	if err != nil {
+		return err
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	// resolve the columns once for all rows
+	fields := make([]*structutils.Field, len(columns))
+	for i, column := range columns {
+		if f, ok := s.Lookup(column); ok {
+			fields[i] = f
+		}
+	}
+
+	ptrs := make([]interface{}, len(columns))
+	var discard interface{}
+	for rows.Next() {
+		elem := reflect.New(structType)
+		for i, f := range fields {
+			if f == nil {
+				ptrs[i] = &discard
+				continue
+			}
+			ptrs[i] = structutils.FieldValueAlloc(elem.Elem(), f.Index).Addr().Interface()
+		}
+
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+
+		if isPtr {
+			slice.Set(reflect.Append(slice, elem))
+		} else {
+			slice.Set(reflect.Append(slice, elem.Elem()))
+		}
+	}
+
+	return rows.Err()
+}
+
+// QueryStructs runs the query and scans the rows into dest with ScanStructs.
+func QueryStructs(ctx context.Context, q Queryer, dest any, query string, args ...interface{}) error {
+	if q == nil {
+		return ErrNoQueryer
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return ScanStructs(rows, dest)
+}
@@ -0,0 +1,239 @@
+// Package hook runs middleware around building and executing queries. Hooks
+// are registered on a strategy instance with Use and apply to every builder
+// created with that strategy.
+package hook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/faciam-dev/goquent-query-builder/executor"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+const (
+	KindSelect = "select"
+	KindInsert = "insert"
+	KindUpdate = "update"
+	KindDelete = "delete"
+)
+
+var (
+	// ErrWhereNotSupported is returned by Event.Where for insert queries.
+	ErrWhereNotSupported = errors.New("hook: the query has no WHERE clause")
+	// ErrAmbiguousWhere is returned by Event.Where when the existing conditions
+	// are joined with a top level OR that cannot be parenthesized, so an added
+	// condition would not apply to every row.
+	ErrAmbiguousWhere = errors.New("hook: cannot add a condition next to a top level OR")
+	// ErrWhereUnion is returned by Event.Where for a select with unions, as
+	// the condition would only apply to its last query.
+	ErrWhereUnion = errors.New("hook: cannot add a condition to a query with unions")
+)
+
+// Event is the query passed through the hooks. Exactly one of Select, Insert,
+// Update and Delete is set before rendering; SQL and Args are set after it.
+// During execution only Kind, SQL and Args are set.
+//
+// The query structures are copies of the builder state, so fields can be
+// replaced freely; slices and maps they point to are shared with the builder
+// and must not be modified in place.
+type Event struct {
+	Kind   string
+	Select *structs.Query
+	// Unions are the queries a select combines with Select, rendered before
+	// it.
+	Unions []structs.Union
+	Insert *structs.InsertQuery
+	Update *structs.UpdateQuery
+	Delete *structs.DeleteQuery
+	SQL    string
+	Args   []interface{}
+}
+
+// Hook is a middleware around building and executing queries.
+type Hook interface {
+	// BeforeBuild is called with the query structure before it is rendered.
+	BeforeBuild(e *Event) error
+	// AfterBuild is called with the rendered SQL and arguments and may replace
+	// them.
+	AfterBuild(e *Event) error
+	// Execute wraps the execution of a statement by the terminal methods of
+	// the builders. It must call next to run the statement.
+	Execute(ctx context.Context, e *Event, next func(ctx context.Context) error) error
+}
+
+// Funcs implements Hook with optional functions.
+type Funcs struct {
+	Before func(e *Event) error
+	After  func(e *Event) error
+	Around func(ctx context.Context, e *Event, next func(ctx context.Context) error) error
+}
+
+func (f Funcs) BeforeBuild(e *Event) error {
+	if f.Before == nil {
+		return nil
+	}
+	return f.Before(e)
+}
+
+func (f Funcs) AfterBuild(e *Event) error {
+	if f.After == nil {
+		return nil
+	}
+	return f.After(e)
+}
+
+func (f Funcs) Execute(ctx context.Context, e *Event, next func(ctx context.Context) error) error {
+	if f.Around == nil {
+		return next(ctx)
+	}
+	return f.Around(ctx, e, next)
+}
+
+// Chain is the list of hooks of a strategy. A nil Chain has no hooks.
+type Chain struct {
+	hooks []Hook
+}
+
+// Use appends hooks. It is not safe to call while queries are being built.
+func (c *Chain) Use(hooks ...Hook) {
+	c.hooks = append(c.hooks, hooks...)
+}
+
+func (c *Chain) Len() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.hooks)
+}
+
+// BeforeBuild calls the hooks in the order they were added.
+func (c *Chain) BeforeBuild(e *Event) error {
+	for i := 0; i < c.Len(); i++ {
+		if err := c.hooks[i].BeforeBuild(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AfterBuild calls the hooks in reverse order, so the first hook sees the
+// final SQL.
+func (c *Chain) AfterBuild(e *Event) error {
+	for i := c.Len() - 1; i >= 0; i-- {
+		if err := c.hooks[i].AfterBuild(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Execute runs fn wrapped by the hooks; the first hook is the outermost.
+func (c *Chain) Execute(ctx context.Context, e *Event, fn func(ctx context.Context) error) error {
+	next := fn
+	for i := c.Len() - 1; i >= 0; i-- {
+		h, inner := c.hooks[i], next
+		next = func(ctx context.Context) error {
+			return h.Execute(ctx, e, inner)
+		}
+	}
+	return next(ctx)
+}
+
+// Wrap returns q with its statements run through Execute. q is returned as is
+// when there are no hooks.
+func (c *Chain) Wrap(q executor.Queryer, kind string) executor.Queryer {
+	if c.Len() == 0 || q == nil {
+		return q
+	}
+	return &queryer{q: q, chain: c, kind: kind}
+}
+
+type queryer struct {
+	q     executor.Queryer
+	chain *Chain
+	kind  string
+}
+
+func (w *queryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	e := &Event{Kind: w.kind, SQL: query, Args: args}
+	var rows *sql.Rows
+	err := w.chain.Execute(ctx, e, func(ctx context.Context) error {
+		var err error
+		rows, err = w.q.QueryContext(ctx, e.SQL, e.Args...)
+		return err
+	})
+	return rows, err
+}
+
+func (w *queryer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	e := &Event{Kind: w.kind, SQL: query, Args: args}
+	var res sql.Result
+	err := w.chain.Execute(ctx, e, func(ctx context.Context) error {
+		var err error
+		res, err = w.q.ExecContext(ctx, e.SQL, e.Args...)
+		return err
+	})
+	return res, err
+}
+
+// Where adds "column operator value" to the WHERE clause of a select, update
+// or delete query with AND. A single group of conditions joined with OR is
+// parenthesized first.
+//
+// Only the outer query gets the condition: subqueries and common table
+// expressions are left unchanged, and a select with unions returns
+// ErrWhereUnion. Filters that must apply to every query on a table, such as
+// a tenant filter, belong in a global scope.
+func (e *Event) Where(column string, operator string, value interface{}) error {
+	var q *structs.Query
+	switch {
+	case e.Select != nil && len(e.Unions) > 0:
+		return ErrWhereUnion
+	case e.Select != nil:
+		q = e.Select
+	case e.Update != nil && e.Update.Query != nil:
+		q = e.Update.Query
+	case e.Delete != nil && e.Delete.Query != nil:
+		q = e.Delete.Query
+	default:
+		return ErrWhereNotSupported
+	}
+
+	groups := append([]structs.WhereGroup(nil), q.ConditionGroups...)
+	if len(groups) == 1 && groups[0].IsDummyGroup && len(groups[0].Conditions) > 1 && hasOr(groups[0].Conditions[1:]) {
+		groups[0].IsDummyGroup = false
+	} else if len(groups) > 1 {
+		for i, g := range groups {
+			conditions := g.Conditions
+			if i == 0 && len(conditions) > 0 {
+				conditions = conditions[1:]
+			}
+			if (!g.IsDummyGroup && i > 0 && g.Operator == consts.LogicalOperator_OR) || (g.IsDummyGroup && hasOr(conditions)) {
+				return ErrAmbiguousWhere
+			}
+		}
+	}
+
+	q.ConditionGroups = append(groups, structs.WhereGroup{
+		Conditions: []structs.Where{{
+			Column:    column,
+			Condition: operator,
+			Value:     []interface{}{value},
+			Operator:  consts.LogicalOperator_AND,
+		}},
+		Operator: consts.LogicalOperator_AND,
+	})
+	return nil
+}
+
+func hasOr(conditions []structs.Where) bool {
+	for _, c := range conditions {
+		if c.Operator == consts.LogicalOperator_OR {
+			return true
+		}
+	}
+	return false
+}
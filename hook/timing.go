@@ -0,0 +1,33 @@
+package hook
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Timing calls fn with the duration and error of every executed statement,
+// e.g. to record metrics.
+func Timing(fn func(e *Event, d time.Duration, err error)) Hook {
+	return Funcs{
+		Around: func(ctx context.Context, e *Event, next func(ctx context.Context) error) error {
+			start := time.Now()
+			err := next(ctx)
+			fn(e, time.Since(start), err)
+			return err
+		},
+	}
+}
+
+// SlowQuery logs statements that take at least threshold to execute. A nil
+// logger uses the standard logger.
+func SlowQuery(threshold time.Duration, logger *log.Logger) Hook {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return Timing(func(e *Event, d time.Duration, err error) {
+		if d >= threshold {
+			logger.Printf("slow query (%s): %s %v", d, e.SQL, e.Args)
+		}
+	})
+}
@@ -51,6 +51,49 @@ const (
 	Order_FLAG_DESC = false
 )
 
+const (
+	WindowFrame_ROWS  = "ROWS"
+	WindowFrame_RANGE = "RANGE"
+)
+
+const (
+	Schema_CREATE               = "create"
+	Schema_CREATE_IF_NOT_EXISTS = "create_if_not_exists"
+	Schema_ALTER                = "alter"
+	Schema_DROP                 = "drop"
+	Schema_DROP_IF_EXISTS       = "drop_if_exists"
+	Schema_RENAME               = "rename"
+)
+
+const (
+	ColumnType_INCREMENTS     = "increments"
+	ColumnType_BIG_INCREMENTS = "big_increments"
+	ColumnType_INTEGER        = "integer"
+	ColumnType_BIG_INTEGER    = "big_integer"
+	ColumnType_BOOLEAN        = "boolean"
+	ColumnType_STRING         = "string"
+	ColumnType_TEXT           = "text"
+	ColumnType_JSON           = "json"
+	ColumnType_JSONB          = "jsonb"
+	ColumnType_DATE           = "date"
+	ColumnType_TIMESTAMP      = "timestamp"
+	ColumnType_TIMESTAMP_TZ   = "timestamp_tz"
+	ColumnType_DECIMAL        = "decimal"
+	ColumnType_UUID           = "uuid"
+	ColumnType_ENUM           = "enum"
+
+	ColumnType_Default_String_Length = 255
+	ColumnType_Default_Precision     = 8
+	ColumnType_Default_Scale         = 2
+)
+
+const (
+	Index_INDEX    = "index"
+	Index_UNIQUE   = "unique"
+	Index_PRIMARY  = "primary"
+	Index_FULLTEXT = "fulltext"
+)
+
 const (
 	Lock_FOR_UPDATE = "FOR UPDATE"
 	Lock_SHARE_MODE = "LOCK IN SHARE MODE"
@@ -74,4 +117,6 @@ const (
 	DialectBase       = "base"
 	DialectMySQL      = "mysql"
 	DialectPostgreSQL = "postgres"
+	DialectSQLite     = "sqlite"
+	DialectSQLServer  = "sqlserver"
 )
@@ -108,6 +108,33 @@ func InlinePlaceholders(sql string, args []interface{}) (string, error) {
 				return "", 0, false, fmt.Errorf("placeholder index out of range: $%d", position)
 			}
 
+			replacement, err := formatInlinePlaceholderValue(args[position-1])
+			if err != nil {
+				return "", 0, false, err
+			}
+			usedNumbered[position-1] = true
+			return replacement, end, true, nil
+		case '@':
+			// SQL Server style @p1, @p2, ...
+			if i+1 >= len(src) || src[i+1] != 'p' {
+				return "", 0, false, nil
+			}
+			end := i + 2
+			for end < len(src) && src[end] >= '0' && src[end] <= '9' {
+				end++
+			}
+			if end == i+2 {
+				return "", 0, false, nil
+			}
+
+			position, err := strconv.Atoi(src[i+2 : end])
+			if err != nil {
+				return "", 0, false, err
+			}
+			if position <= 0 || position > len(args) {
+				return "", 0, false, fmt.Errorf("placeholder index out of range: @p%d", position)
+			}
+
 			replacement, err := formatInlinePlaceholderValue(args[position-1])
 			if err != nil {
 				return "", 0, false, err
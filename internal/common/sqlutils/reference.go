@@ -37,48 +37,66 @@ func RelationSelectReference(value string) string {
 }
 
 func AppendEscapedRelation(sb []byte, value string, quote byte) []byte {
+	return AppendEscapedRelationPair(sb, value, quote, quote)
+}
+
+func AppendEscapedReference(sb []byte, value string, quote byte) []byte {
+	return AppendEscapedReferencePair(sb, value, quote, quote)
+}
+
+func AppendEscapedAliasedValue(sb []byte, value string, quote byte) []byte {
+	return AppendEscapedAliasedValuePair(sb, value, quote, quote)
+}
+
+// AppendEscapedRelationPair escapes a relation using distinct opening and
+// closing quote characters, such as SQL Server's [brackets].
+func AppendEscapedRelationPair(sb []byte, value string, open, close byte) []byte {
 	trimmed := strings.TrimSpace(value)
 	ref, ok := ParseRelationReference(trimmed)
 	if !ok {
-		return appendQuotedIdentifier(sb, trimmed, quote)
+		return appendQuotedIdentifier(sb, trimmed, open, close)
 	}
 
-	sb = appendEscapedQualifiedReference(sb, ref.Parts, quote)
+	sb = appendEscapedQualifiedReference(sb, ref.Parts, open, close)
 	if ref.Alias == "" {
 		return sb
 	}
 
 	sb = append(sb, " as "...)
-	return appendQuotedIdentifier(sb, ref.Alias, quote)
+	return appendQuotedIdentifier(sb, ref.Alias, open, close)
 }
 
-func AppendEscapedReference(sb []byte, value string, quote byte) []byte {
+// AppendEscapedReferencePair escapes a column reference using distinct opening
+// and closing quote characters.
+func AppendEscapedReferencePair(sb []byte, value string, open, close byte) []byte {
 	trimmed := strings.TrimSpace(value)
 	ref, ok := ParseReference(trimmed)
 	if !ok {
-		return appendQuotedIdentifier(sb, trimmed, quote)
+		return appendQuotedIdentifier(sb, trimmed, open, close)
 	}
 
-	return appendEscapedQualifiedReference(sb, ref.Parts, quote)
+	return appendEscapedQualifiedReference(sb, ref.Parts, open, close)
 }
 
-func AppendEscapedAliasedValue(sb []byte, value string, quote byte) []byte {
+// AppendEscapedAliasedValuePair escapes an aliased value using distinct opening
+// and closing quote characters.
+func AppendEscapedAliasedValuePair(sb []byte, value string, open, close byte) []byte {
 	trimmed := strings.TrimSpace(value)
 	ref, ok := ParseAliasedValue(trimmed)
 	if !ok {
-		return appendQuotedIdentifier(sb, trimmed, quote)
+		return appendQuotedIdentifier(sb, trimmed, open, close)
 	}
 
-	sb = appendEscapedQualifiedReference(sb, ref.Parts, quote)
+	sb = appendEscapedQualifiedReference(sb, ref.Parts, open, close)
 	if ref.Alias == "" {
 		return sb
 	}
 
 	sb = append(sb, " as "...)
-	return appendQuotedIdentifier(sb, ref.Alias, quote)
+	return appendQuotedIdentifier(sb, ref.Alias, open, close)
 }
 
-func appendEscapedQualifiedReference(sb []byte, parts []string, quote byte) []byte {
+func appendEscapedQualifiedReference(sb []byte, parts []string, open, close byte) []byte {
 	for i, part := range parts {
 		if i > 0 {
 			sb = append(sb, '.')
@@ -87,20 +105,20 @@ func appendEscapedQualifiedReference(sb []byte, parts []string, quote byte) []by
 			sb = append(sb, '*')
 			continue
 		}
-		sb = appendQuotedIdentifier(sb, part, quote)
+		sb = appendQuotedIdentifier(sb, part, open, close)
 	}
 	return sb
 }
 
-func appendQuotedIdentifier(sb []byte, value string, quote byte) []byte {
-	sb = append(sb, quote)
+func appendQuotedIdentifier(sb []byte, value string, open, close byte) []byte {
+	sb = append(sb, open)
 	for i := 0; i < len(value); i++ {
 		sb = append(sb, value[i])
-		if value[i] == quote {
-			sb = append(sb, quote)
+		if value[i] == close {
+			sb = append(sb, close)
 		}
 	}
-	sb = append(sb, quote)
+	sb = append(sb, close)
 	return sb
 }
 
@@ -181,7 +199,7 @@ func parseReferencePart(value string, start int) (string, int, bool) {
 	}
 
 	switch value[start] {
-	case '"', '`':
+	case '"', '`', '[':
 		return parseQuotedReferencePart(value, start)
 	}
 
@@ -204,7 +222,7 @@ func parseReferencePart(value string, start int) (string, int, bool) {
 }
 
 func parseQuotedReferencePart(value string, start int) (string, int, bool) {
-	quote := value[start]
+	quote := closingQuote(value[start])
 	pos := start + 1
 	segmentStart := pos
 	var b strings.Builder
@@ -249,7 +267,7 @@ func splitTopLevelWhitespace(value string) ([]string, bool) {
 		start := i
 		for i < len(value) {
 			switch value[i] {
-			case '"', '`':
+			case '"', '`', '[':
 				next, ok := skipQuotedToken(value, i)
 				if !ok {
 					return nil, false
@@ -274,7 +292,7 @@ func splitTopLevelWhitespace(value string) ([]string, bool) {
 }
 
 func skipQuotedToken(value string, start int) (int, bool) {
-	quote := value[start]
+	quote := closingQuote(value[start])
 	for i := start + 1; i < len(value); i++ {
 		if value[i] != quote {
 			continue
@@ -288,6 +306,13 @@ func skipQuotedToken(value string, start int) (int, bool) {
 	return 0, false
 }
 
+func closingQuote(open byte) byte {
+	if open == '[' {
+		return ']'
+	}
+	return open
+}
+
 func asciiEqualFold(left, right string) bool {
 	if len(left) != len(right) {
 		return false
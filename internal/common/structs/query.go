@@ -7,6 +7,36 @@ type Column struct {
 	Distinct bool
 	Count    bool
 	Function string
+	Window   *WindowFunction
+}
+
+// WindowFunction is a window function call rendered as fn(args) OVER (...),
+// or Raw OVER (...) when Raw is set. The alias is taken from Column.Name.
+type WindowFunction struct {
+	Function string
+	Args     []string
+	Raw      string
+	Spec     WindowSpec
+}
+
+// WindowSpec is the part between the parentheses of an OVER clause. Name
+// refers to a window defined in the WINDOW clause.
+type WindowSpec struct {
+	Name        string
+	PartitionBy []string
+	Order       []Order
+	Frame       *WindowFrame
+}
+
+type WindowFrame struct {
+	Unit  string
+	Start string
+	End   string
+}
+
+type NamedWindow struct {
+	Name string
+	Spec WindowSpec
 }
 
 type Table struct {
@@ -28,6 +58,17 @@ type Where struct {
 	JsonLength   *JsonLength
 	Raw          string
 	Function     string
+	Keyset       *Keyset
+	// Nested is rendered in parentheses as a single condition.
+	Nested []WhereGroup
+}
+
+// Keyset compares the order columns with the values of the last row seen.
+// Desc holds the direction of each column.
+type Keyset struct {
+	Columns []string
+	Desc    []bool
+	Values  []interface{}
 }
 
 type WhereBetween struct {
@@ -81,6 +122,18 @@ type Query struct {
 	Order           *[]Order
 	Group           *GroupBy
 	Lock            *Lock
+	With            []CTE
+	Windows         []NamedWindow
+}
+
+// CTE is a common table expression rendered in the WITH clause.
+type CTE struct {
+	Name           string
+	Columns        []string
+	Query          *Query
+	RecursiveQuery *Query
+	Recursive      bool
+	Materialized   bool
 }
 
 type Union struct {
@@ -96,6 +149,7 @@ type SelectQuery struct {
 	Union   *[]Union
 	Group   *GroupBy
 	Lock    *Lock
+	Windows []NamedWindow
 }
 
 type InsertQuery struct {
@@ -106,22 +160,57 @@ type InsertQuery struct {
 	Query       *Query
 	Ignore      bool
 	Upsert      *Upsert
+	Returning   []string
 }
 
 type Upsert struct {
 	UniqueColumns []string
 	UpdateColumns []string
+	// UpdateValues sets columns of the conflicting rows to values instead of
+	// the inserted ones.
+	UpdateValues map[string]interface{}
+}
+
+// Expression is a value rendered as SQL instead of a placeholder. Values are
+// bound to the ? placeholders of SQL.
+type Expression struct {
+	SQL    string
+	Values []interface{}
+}
+
+// Arithmetic is an update value computed from the current value of the
+// column it is assigned to: column Operator Amount.
+type Arithmetic struct {
+	Operator string
+	Amount   interface{}
+}
+
+// Source is a table, or a subquery named Name, that an UPDATE or DELETE reads
+// besides its target table.
+type Source struct {
+	Name  string
+	Query *Query
 }
 
 type UpdateQuery struct {
-	Table  string
-	Values map[string]interface{}
-	Query  *Query
+	Table     string
+	Values    map[string]interface{}
+	Query     *Query
+	Returning []string
+	From      []Source
+	// LimitKey is the column matching the rows of a LIMIT emulated with a
+	// subquery.
+	LimitKey string
 }
 
 type DeleteQuery struct {
-	Table string
-	Query *Query
+	Table     string
+	Query     *Query
+	Returning []string
+	Using     []Source
+	// LimitKey is the column matching the rows of a LIMIT emulated with a
+	// subquery.
+	LimitKey string
 }
 
 type On struct {
@@ -0,0 +1,55 @@
+package structs
+
+// SchemaQuery is a DDL command. Table is used by create and alter, To by
+// rename.
+type SchemaQuery struct {
+	Command string
+	Table   SchemaTable
+	To      string
+}
+
+type SchemaTable struct {
+	Name            string
+	Columns         []SchemaColumn
+	Indexes         []SchemaIndex
+	ForeignKeys     []ForeignKey
+	DropColumns     []string
+	RenameColumns   []RenameColumn
+	DropIndexes     []string
+	DropForeignKeys []string
+}
+
+type SchemaColumn struct {
+	Name       string
+	Type       string
+	Length     int
+	Precision  int
+	Scale      int
+	Values     []string
+	Nullable   bool
+	Default    interface{}
+	HasDefault bool
+	DefaultRaw string
+	Unsigned   bool
+	Primary    bool
+}
+
+type SchemaIndex struct {
+	Name    string
+	Type    string
+	Columns []string
+}
+
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+type RenameColumn struct {
+	From string
+	To   string
+}
@@ -0,0 +1,48 @@
+package structs
+
+import "github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+
+// NestedGroup returns a group rendering groups in parentheses, joined to the
+// preceding groups with AND.
+func NestedGroup(groups []WhereGroup) WhereGroup {
+	return WhereGroup{
+		Conditions: []Where{{
+			Nested:   groups,
+			Operator: consts.LogicalOperator_AND,
+		}},
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	}
+}
+
+// HasConditions reports whether any of groups has a condition.
+func HasConditions(groups []WhereGroup) bool {
+	for _, g := range groups {
+		if len(g.Conditions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTopLevelOr reports whether groups, as rendered, join any two conditions
+// outside of parentheses with OR.
+func HasTopLevelOr(groups []WhereGroup) bool {
+	for i, g := range groups {
+		if len(g.Conditions) == 0 {
+			continue
+		}
+		if !g.IsDummyGroup {
+			if i > 0 && g.Operator == consts.LogicalOperator_OR {
+				return true
+			}
+			continue
+		}
+		for j, c := range g.Conditions {
+			if (i > 0 || j > 0) && c.Operator == consts.LogicalOperator_OR {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package structutils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Field is a struct field mapped to a column with the db tag
+// `db:"name,omitempty,readonly,pk"`.
+type Field struct {
+	Column    string
+	Index     []int
+	OmitEmpty bool
+	ReadOnly  bool
+	PK        bool
+}
+
+// Struct is the cached column mapping of a struct type. Fields of embedded
+// structs are flattened into it.
+type Struct struct {
+	Fields   []Field
+	byColumn map[string]int
+}
+
+var cache sync.Map
+
+// ErrNotStruct is returned when a value is not a struct or a pointer to one.
+var ErrNotStruct = errors.New("value is not a struct")
+
+// TypeOf returns the mapping of a struct type, building it on first use.
+func TypeOf(t reflect.Type) (*Struct, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%w: %s", ErrNotStruct, t)
+	}
+
+	if s, ok := cache.Load(t); ok {
+		return s.(*Struct), nil
+	}
+
+	s := &Struct{byColumn: make(map[string]int)}
+	collectFields(s, t, nil)
+	for i, f := range s.Fields {
+		if _, ok := s.byColumn[f.Column]; !ok {
+			s.byColumn[f.Column] = i
+		}
+	}
+	// "users.name" is also found as "name" unless another field is called so.
+	for i, f := range s.Fields {
+		if short := lastSegment(f.Column); short != f.Column {
+			if _, ok := s.byColumn[short]; !ok {
+				s.byColumn[short] = i
+			}
+		}
+	}
+
+	actual, _ := cache.LoadOrStore(t, s)
+	return actual.(*Struct), nil
+}
+
+// Lookup finds the field for a result column. A qualified column such as
+// "users.name" falls back to "name".
+func (s *Struct) Lookup(column string) (*Field, bool) {
+	if i, ok := s.byColumn[column]; ok {
+		return &s.Fields[i], true
+	}
+	if short := lastSegment(column); short != column {
+		if i, ok := s.byColumn[short]; ok {
+			return &s.Fields[i], true
+		}
+	}
+	return nil, false
+}
+
+func collectFields(s *Struct, t reflect.Type, index []int) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = i
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && !hasTag && ft.Kind() == reflect.Struct {
+			collectFields(s, ft, fieldIndex)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		f := Field{Index: fieldIndex}
+		parts := strings.Split(tag, ",")
+		f.Column = parts[0]
+		for _, opt := range parts[1:] {
+			switch strings.TrimSpace(opt) {
+			case "omitempty":
+				f.OmitEmpty = true
+			case "readonly":
+				f.ReadOnly = true
+			case "pk":
+				f.PK = true
+			}
+		}
+		if f.Column == "" {
+			f.Column = ToSnakeCase(sf.Name)
+		}
+
+		s.Fields = append(s.Fields, f)
+	}
+}
+
+// ToSnakeCase converts a Go field name such as "UserID" to "user_id".
+func ToSnakeCase(name string) string {
+	runes := []rune(name)
+	sb := make([]rune, 0, len(runes)+4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				sb = append(sb, '_')
+			}
+			r = unicode.ToLower(r)
+		}
+		sb = append(sb, r)
+	}
+	return string(sb)
+}
+
+func lastSegment(column string) string {
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		return column[i+1:]
+	}
+	return column
+}
+
+// FieldValue returns the field of v at index. ok is false when a nil embedded
+// pointer is on the way.
+func FieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// FieldValueAlloc is like FieldValue but allocates nil embedded pointers, so
+// the field can be scanned into.
+func FieldValueAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
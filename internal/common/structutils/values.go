@@ -0,0 +1,121 @@
+package structutils
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// InsertValues returns the columns of v for an INSERT. Read-only fields are
+// skipped, as are zero valued primary keys and omitempty fields.
+func InsertValues(v any) (map[string]interface{}, error) {
+	rv, s, err := structValue(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]interface{}, len(s.Fields))
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		if f.ReadOnly {
+			continue
+		}
+		fv, ok := FieldValue(rv, f.Index)
+		if !ok {
+			continue
+		}
+		if (f.OmitEmpty || f.PK) && fv.IsZero() {
+			continue
+		}
+		values[f.Column] = fv.Interface()
+	}
+
+	return values, nil
+}
+
+// InsertValuesBatch returns InsertValues for every element of a slice.
+func InsertValuesBatch(slice any) ([]map[string]interface{}, error) {
+	rv := reflect.ValueOf(slice)
+	for rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("value is not a slice: %T", slice)
+	}
+
+	batch := make([]map[string]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		values, err := InsertValues(rv.Index(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		batch = append(batch, values)
+	}
+
+	return batch, nil
+}
+
+// UpdateValues returns the SET columns and the primary key columns of v.
+// Read-only fields are skipped; omitempty fields, and every field when
+// omitZero is set, are skipped when zero. columns restricts the SET columns
+// when not empty.
+func UpdateValues(v any, columns []string, omitZero bool) (map[string]interface{}, map[string]interface{}, error) {
+	rv, s, err := structValue(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var only map[string]struct{}
+	if len(columns) > 0 {
+		only = make(map[string]struct{}, len(columns))
+		for _, c := range columns {
+			only[c] = struct{}{}
+		}
+	}
+
+	values := make(map[string]interface{}, len(s.Fields))
+	keys := make(map[string]interface{})
+	for i := range s.Fields {
+		f := &s.Fields[i]
+		fv, ok := FieldValue(rv, f.Index)
+		if !ok {
+			continue
+		}
+		if f.PK {
+			keys[f.Column] = fv.Interface()
+			continue
+		}
+		if f.ReadOnly {
+			continue
+		}
+		if only != nil {
+			if _, ok := only[f.Column]; !ok {
+				continue
+			}
+		}
+		if (f.OmitEmpty || omitZero) && fv.IsZero() {
+			continue
+		}
+		values[f.Column] = fv.Interface()
+	}
+
+	return values, keys, nil
+}
+
+func structValue(v any) (reflect.Value, *Struct, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}, nil, fmt.Errorf("%w: nil %T", ErrNotStruct, v)
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() {
+		return reflect.Value{}, nil, fmt.Errorf("%w: %T", ErrNotStruct, v)
+	}
+
+	s, err := TypeOf(rv.Type())
+	if err != nil {
+		return reflect.Value{}, nil, err
+	}
+	return rv, s, nil
+}
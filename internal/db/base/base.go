@@ -1,11 +1,16 @@
 package base
 
 import (
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
 )
 
 type BaseQueryBuilder struct {
+	WithBaseBuilder
+	WindowBaseBuilder
 	UnionBaseBuilder
 	SelectBaseBuilder
 	FromBaseBuilder
@@ -18,17 +23,27 @@ type BaseQueryBuilder struct {
 	InsertBaseBuilder
 	UpdateBaseBuilder
 	DeleteBaseBuilder
+	SchemaBaseBuilder
 
-	util interfaces.SQLUtils
+	util       interfaces.SQLUtils
+	hooks      *hook.Chain
+	scopes     *scope.Registry
+	timestamps *timestamp.Registry
 }
 
 func NewBaseQueryBuilder() *BaseQueryBuilder {
-	return newBaseQueryBuilderWithUtil(NewSQLUtils())
+	return NewBaseQueryBuilderWithUtil(NewSQLUtils())
 }
 
-func newBaseQueryBuilderWithUtil(u interfaces.SQLUtils) *BaseQueryBuilder {
+// NewBaseQueryBuilderWithUtil returns a base builder whose renderers quote and
+// number placeholders with u.
+func NewBaseQueryBuilderWithUtil(u interfaces.SQLUtils) *BaseQueryBuilder {
 	queryBuilder := &BaseQueryBuilder{}
 	queryBuilder.util = u
+	queryBuilder.scopes = &scope.Registry{}
+	queryBuilder.timestamps = &timestamp.Registry{}
+	queryBuilder.WithBaseBuilder = *NewWithBaseBuilder(u)
+	queryBuilder.WindowBaseBuilder = *NewWindowBaseBuilder(u)
 	queryBuilder.SelectBaseBuilder = *NewSelectBaseBuilder(u, &[]string{})
 	queryBuilder.FromBaseBuilder = *NewFromBaseBuilder(u)
 	queryBuilder.JoinBaseBuilder = *NewJoinBaseBuilder(u, &structs.Joins{})
@@ -40,12 +55,49 @@ func newBaseQueryBuilderWithUtil(u interfaces.SQLUtils) *BaseQueryBuilder {
 	queryBuilder.InsertBaseBuilder = *NewInsertBaseBuilder(u, &structs.InsertQuery{})
 	queryBuilder.UpdateBaseBuilder = *NewUpdateBaseBuilder(u, &structs.UpdateQuery{})
 	queryBuilder.DeleteBaseBuilder = *NewDeleteBaseBuilder(u, &structs.DeleteQuery{})
+	queryBuilder.SchemaBaseBuilder = *NewSchemaBaseBuilder(u)
 	return queryBuilder
 }
 
 func (BaseQueryBuilder) ResetPlaceholderCounter() {
 }
 
+// ForBuild returns the strategy of the SQLUtils the builder was created with.
+// A dialect composed on BaseQueryBuilder whose SQLUtils keeps state, such as a
+// placeholder counter, must override it to return a strategy with its own
+// SQLUtils.
+func (m BaseQueryBuilder) ForBuild() interfaces.QueryBuilderStrategy {
+	return m.util.GetQueryBuilderStrategy()
+}
+
+// Dialect returns the name of the SQL dialect.
+func (m BaseQueryBuilder) Dialect() string {
+	return m.util.Dialect()
+}
+
+// Use registers hooks which run for every query built with this strategy.
+func (m *BaseQueryBuilder) Use(hooks ...hook.Hook) {
+	if m.hooks == nil {
+		m.hooks = &hook.Chain{}
+	}
+	m.hooks.Use(hooks...)
+}
+
+// Hooks returns the hooks registered with Use.
+func (m BaseQueryBuilder) Hooks() *hook.Chain {
+	return m.hooks
+}
+
+// Scopes returns the named and global scopes of the strategy.
+func (m BaseQueryBuilder) Scopes() *scope.Registry {
+	return m.scopes
+}
+
+// Timestamps returns the timestamp columns of the tables of the strategy.
+func (m BaseQueryBuilder) Timestamps() *timestamp.Registry {
+	return m.timestamps
+}
+
 // Lock returns the lock statement.
 func (BaseQueryBuilder) Lock(sb *[]byte, lock *structs.Lock) {
 	if lock == nil || lock.LockType == "" {
@@ -60,6 +112,13 @@ func (BaseQueryBuilder) Lock(sb *[]byte, lock *structs.Lock) {
 func (m BaseQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
 	values := make([]interface{}, 0)
 
+	// WITH
+	withValues, err := m.With(sb, q, number, unions)
+	if err != nil {
+		return nil, err
+	}
+	values = append(values, withValues...)
+
 	// SELECT
 	*sb = append(*sb, "SELECT "...)
 	colValues, err := m.Select(sb, q.Columns, q.Table.Name, q.Joins)
@@ -87,6 +146,11 @@ func (m BaseQueryBuilder) Build(sb *[]byte, q *structs.Query, number int, unions
 	groupByValues := m.GroupBy(sb, q.Group)
 	values = append(values, groupByValues...)
 
+	// WINDOW
+	if err := m.Window(sb, q.Windows); err != nil {
+		return nil, err
+	}
+
 	// ORDER BY
 	m.OrderBy(sb, q.Order)
 
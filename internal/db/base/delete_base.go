@@ -1,6 +1,9 @@
 package base
 
 import (
+	"fmt"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/memutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/sqlutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
@@ -24,6 +27,9 @@ func (m *DeleteBaseBuilder) Delete(q *structs.DeleteQuery) *DeleteBaseBuilder {
 // DeleteBatch builds the Delete query for Delete.
 func (m *DeleteBaseBuilder) BuildDelete(q *structs.DeleteQuery) (string, []interface{}, error) {
 	//values := make([]interface{}, 0)
+	if err := checkReturning(m.u, q.Returning); err != nil {
+		return "", nil, err
+	}
 
 	ptr := poolBytes.Get().(*[]byte)
 	sb := *ptr
@@ -37,27 +43,75 @@ func (m *DeleteBaseBuilder) BuildDelete(q *structs.DeleteQuery) (string, []inter
 		values = values[0:0]
 	}
 
+	// WITH
+	withValues, err := NewWithBaseBuilder(m.u).BuildWith(&sb, q.Query.With)
+	if err != nil {
+		return "", nil, err
+	}
+	values = append(values, withValues...)
+
+	rl, err := limitRows(m.u, q.Table, q.Query, q.LimitKey, len(q.Using) > 0 || hasJoins(q.Query.Joins))
+	if err != nil {
+		return "", nil, err
+	}
+
 	// DELETE
 	sb = append(sb, "DELETE"...)
-	if q.Query.Joins != nil &&
-		q.Query.Joins.Joins != nil &&
-		(len(*q.Query.Joins.Joins) > 0 || (q.Query.Joins.JoinClauses != nil && len(*q.Query.Joins.JoinClauses) > 0)) {
+	sb = rl.appendTop(sb)
+	groups := rl.groups
+	switch {
+	case m.u.Dialect() == consts.DialectPostgreSQL:
+		// the joined tables are listed in USING and joined in WHERE
+		sources, on, err := joinSources(m.u, q.Query.Joins)
+		if err != nil {
+			return "", nil, err
+		}
+		groups = joinWhere(on, groups)
+
+		sb = append(sb, " FROM "...)
+		sb = m.u.EscapeRelation(sb, q.Table)
+		if using := append(append([]structs.Source(nil), q.Using...), sources...); len(using) > 0 {
+			sb = append(sb, " USING "...)
+			sb, values, err = appendSources(sb, m.u, using, values)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	case len(q.Using) > 0 || hasJoins(q.Query.Joins):
+		if m.u.Dialect() == consts.DialectSQLite {
+			return "", nil, fmt.Errorf("%w: DELETE on %s", ErrJoinNotSupported, m.u.Dialect())
+		}
+
 		sb = append(sb, " "...)
 		sb = m.u.EscapeReference(sb, sqlutils.RelationSelectReference(q.Table))
+		// OUTPUT comes before FROM when the target is named separately.
+		sb = appendOutput(sb, m.u, "DELETED", q.Returning)
+
+		// FROM
+		sb = append(sb, " FROM "...)
+		sb = m.u.EscapeRelation(sb, q.Table)
+
+		// JOIN
+		jb := NewJoinBaseBuilder(m.u, q.Query.Joins)
+		values = append(values, jb.Join(&sb, q.Query.Joins)...)
+		if len(q.Using) > 0 {
+			sb = append(sb, ", "...)
+			sb, values, err = appendSources(sb, m.u, q.Using, values)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	default:
+		// FROM
+		sb = append(sb, " FROM "...)
+		sb = m.u.EscapeRelation(sb, q.Table)
+		sb = appendOutput(sb, m.u, "DELETED", q.Returning)
 	}
 
-	// FROM
-	sb = append(sb, " FROM "...)
-	sb = m.u.EscapeRelation(sb, q.Table)
-
-	// JOIN
-	jb := NewJoinBaseBuilder(m.u, q.Query.Joins)
-	jb.Join(&sb, q.Query.Joins)
-
 	// WHERE
-	if len(q.Query.ConditionGroups) > 0 {
-		wb := NewWhereBaseBuilder(m.u, q.Query.ConditionGroups)
-		whereValues, err := wb.Where(&sb, q.Query.ConditionGroups)
+	if len(groups) > 0 {
+		wb := NewWhereBaseBuilder(m.u, groups)
+		whereValues, err := wb.Where(&sb, groups)
 		if err != nil {
 			return "", nil, err
 		}
@@ -65,12 +119,16 @@ func (m *DeleteBaseBuilder) BuildDelete(q *structs.DeleteQuery) (string, []inter
 	}
 
 	// ORDER BY
-	if len(*q.Query.Order) > 0 {
-		ob := NewOrderByBaseBuilder(m.u, q.Query.Order)
-		ob.OrderBy(&sb, q.Query.Order)
+	if rl.order != nil && len(*rl.order) > 0 {
+		ob := NewOrderByBaseBuilder(m.u, rl.order)
+		ob.OrderBy(&sb, rl.order)
 	}
 
 	// LIMIT
+	sb = rl.appendLimit(sb)
+
+	// RETURNING
+	sb = appendReturning(sb, m.u, q.Returning)
 
 	query := string(sb)
 
@@ -0,0 +1,60 @@
+package base
+
+import (
+	"sort"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/sqlutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// appendValue appends a placeholder for v to sb and v to values. An
+// Expression is appended as SQL, with its ? placeholders in the form of the
+// dialect, and its values are added instead.
+func appendValue(sb []byte, u interfaces.SQLUtils, v interface{}, values []interface{}) ([]byte, []interface{}, error) {
+	e, ok := v.(structs.Expression)
+	if !ok {
+		return append(sb, u.GetPlaceholder()...), append(values, v), nil
+	}
+
+	expanded, err := sqlutils.ExpandPositionalPlaceholders(e.SQL, len(e.Values), u.GetPlaceholder)
+	if err != nil {
+		return nil, nil, err
+	}
+	return append(sb, expanded...), append(values, e.Values...), nil
+}
+
+// hasExpression reports whether any of values is an Expression.
+func hasExpression(values []interface{}) bool {
+	for _, v := range values {
+		if _, ok := v.(structs.Expression); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUpsertValues appends the assignments of Upsert.UpdateValues, sorted by
+// column, each preceded by ", " unless it is the first of the SET list.
+// column escapes the assigned column.
+func appendUpsertValues(sb []byte, u interfaces.SQLUtils, set map[string]interface{}, first bool, column func(sb []byte, name string) []byte, values []interface{}) ([]byte, []interface{}, error) {
+	columns := make([]string, 0, len(set))
+	for c := range set {
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+
+	var err error
+	for i, c := range columns {
+		if i > 0 || !first {
+			sb = append(sb, ", "...)
+		}
+		sb = column(sb, c)
+		sb = append(sb, " = "...)
+		sb, values, err = appendValue(sb, u, set[c], values)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	return sb, values, nil
+}
@@ -58,9 +58,6 @@ func (m InsertBaseBuilder) Insert(q *structs.InsertQuery) (string, []interface{}
 	sort.Strings(columns)
 
 	values := make([]interface{}, 0, len(columns))
-	for _, column := range columns {
-		values = append(values, q.Values[column])
-	}
 
 	sb = append(sb, "("...)
 	for i, column := range columns {
@@ -69,14 +66,19 @@ func (m InsertBaseBuilder) Insert(q *structs.InsertQuery) (string, []interface{}
 		}
 		sb = m.u.EscapeReference(sb, column)
 	}
-	sb = append(sb, ") "...)
+	sb = append(sb, ")"...)
+	sb = appendOutput(sb, m.u, "INSERTED", q.Returning)
 
-	sb = append(sb, "VALUES ("...)
-	for i := range columns {
+	sb = append(sb, " VALUES ("...)
+	for i, column := range columns {
 		if i > 0 {
 			sb = append(sb, ", "...)
 		}
-		sb = append(sb, m.u.GetPlaceholder()...)
+		var err error
+		sb, values, err = appendValue(sb, m.u, q.Values[column], values)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 	sb = append(sb, ")"...)
 
@@ -96,13 +98,21 @@ func (m InsertBaseBuilder) InsertIgnore(q *structs.InsertQuery) (string, []inter
 		return "", nil, err
 	}
 
-	if m.u.Dialect() == consts.DialectMySQL {
+	return m.applyIgnore(query), values, nil
+}
+
+// applyIgnore rewrites an INSERT statement so that conflicting rows are skipped.
+func (m InsertBaseBuilder) applyIgnore(query string) string {
+	switch m.u.Dialect() {
+	case consts.DialectMySQL:
 		query = strings.Replace(query, "INSERT INTO", "INSERT IGNORE INTO", 1)
-	} else if m.u.Dialect() == consts.DialectPostgreSQL {
+	case consts.DialectPostgreSQL:
 		query += " ON CONFLICT DO NOTHING"
+	case consts.DialectSQLite:
+		query = strings.Replace(query, "INSERT INTO", "INSERT OR IGNORE INTO", 1)
 	}
 
-	return query, values, nil
+	return query
 }
 
 // InsertBatch builds the INSERT query for batch insert.
@@ -147,7 +157,9 @@ func (m InsertBaseBuilder) InsertBatch(q *structs.InsertQuery) (string, []interf
 		}
 		sb = m.u.EscapeReference(sb, column)
 	}
-	sb = append(sb, ") VALUES "...)
+	sb = append(sb, ")"...)
+	sb = appendOutput(sb, m.u, "INSERTED", q.Returning)
+	sb = append(sb, " VALUES "...)
 
 	// VALUES
 	estimatedSize := len(q.ValuesBatch) * len(columns)
@@ -156,30 +168,23 @@ func (m InsertBaseBuilder) InsertBatch(q *structs.InsertQuery) (string, []interf
 		allValues = make([]interface{}, 0, estimatedSize)
 	}
 	for i, values := range q.ValuesBatch {
-		// preallocate rowValues so we can assign by index; nil remains for missing columns
-		rowValues := make([]interface{}, len(columns))
-		for j, col := range columns {
-			if value, ok := values[col]; ok {
-				rowValues[j] = value
-			} else {
-				rowValues[j] = nil
-			}
-		}
-
 		sb = append(sb, "("...)
-		for i := range columns {
-			if i > 0 {
+		for j, col := range columns {
+			if j > 0 {
 				sb = append(sb, ", "...)
 			}
-			sb = append(sb, m.u.GetPlaceholder()...)
+			// missing columns are inserted as NULL
+			var err error
+			sb, allValues, err = appendValue(sb, m.u, values[col], allValues)
+			if err != nil {
+				return "", nil, err
+			}
 		}
 		sb = append(sb, ")"...)
 
 		if i < len(q.ValuesBatch)-1 {
 			sb = append(sb, ", "...)
 		}
-
-		allValues = append(allValues, rowValues...)
 	}
 	query := string(sb)
 
@@ -205,6 +210,23 @@ func (m *InsertBaseBuilder) InsertUsing(q *structs.InsertQuery) (string, []inter
 		sb = sb[:0]
 	}
 
+	// WITH
+	// SQL Server only accepts the WITH clause in front of the INSERT statement,
+	// the other dialects render it as part of the SELECT.
+	selectQuery := q.Query
+	var withValues []interface{}
+	if m.u.Dialect() == consts.DialectSQLServer && len(q.Query.With) > 0 {
+		v, err := NewWithBaseBuilder(m.u).BuildWith(&sb, q.Query.With)
+		if err != nil {
+			return "", nil, err
+		}
+		withValues = v
+
+		withoutCTE := *q.Query
+		withoutCTE.With = nil
+		selectQuery = &withoutCTE
+	}
+
 	// INSERT INTO
 	sb = append(sb, "INSERT INTO "...)
 	sb = m.u.EscapeRelation(sb, q.Table)
@@ -219,11 +241,13 @@ func (m *InsertBaseBuilder) InsertUsing(q *structs.InsertQuery) (string, []inter
 		}
 		sb = m.u.EscapeReference(sb, column)
 	}
-	sb = append(sb, ") "...)
+	sb = append(sb, ")"...)
+	sb = appendOutput(sb, m.u, "INSERTED", q.Returning)
+	sb = append(sb, " "...)
 
 	// SELECT
 	b := m.u.GetQueryBuilderStrategy()
-	selectValues, err := b.Build(&sb, q.Query, 0, nil)
+	selectValues, err := b.Build(&sb, selectQuery, 0, nil)
 	if err != nil {
 		return "", nil, err
 	}
@@ -231,7 +255,7 @@ func (m *InsertBaseBuilder) InsertUsing(q *structs.InsertQuery) (string, []inter
 	query := string(sb)
 
 	// Clone selectValues to avoid clearing returned data
-	retVals := append([]interface{}(nil), selectValues...)
+	retVals := append(withValues, selectValues...)
 
 	memutils.ZeroBytes(sb)
 	sb = sb[:0]
@@ -249,6 +273,10 @@ func (m InsertBaseBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}
 		q.ValuesBatch = []map[string]interface{}{q.Values}
 	}
 
+	if m.u.Dialect() == consts.DialectSQLServer {
+		return m.merge(q)
+	}
+
 	baseQuery, values, err := m.InsertBatch(q)
 	if err != nil {
 		return "", nil, err
@@ -267,7 +295,11 @@ func (m InsertBaseBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}
 			sb = m.u.EscapeReference(sb, col)
 			sb = append(sb, []byte(")")...)
 		}
-	} else if m.u.Dialect() == consts.DialectPostgreSQL {
+		sb, values, err = appendUpsertValues(sb, m.u, q.Upsert.UpdateValues, len(q.Upsert.UpdateColumns) == 0, m.u.EscapeReference, values)
+		if err != nil {
+			return "", nil, err
+		}
+	} else if m.u.Dialect() == consts.DialectPostgreSQL || m.u.Dialect() == consts.DialectSQLite {
 		sb = append(sb, []byte(" ON CONFLICT (")...)
 		for i, col := range q.Upsert.UniqueColumns {
 			if i > 0 {
@@ -284,13 +316,138 @@ func (m InsertBaseBuilder) Upsert(q *structs.InsertQuery) (string, []interface{}
 			sb = append(sb, []byte(" = EXCLUDED.")...)
 			sb = m.u.EscapeReference(sb, col)
 		}
+		sb, values, err = appendUpsertValues(sb, m.u, q.Upsert.UpdateValues, len(q.Upsert.UpdateColumns) == 0, m.u.EscapeReference, values)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return string(sb), values, nil
+}
+
+// merge builds a MERGE statement for dialects without an INSERT level upsert
+// clause. The rows are supplied as a VALUES table aliased as "source".
+func (m InsertBaseBuilder) merge(q *structs.InsertQuery) (string, []interface{}, error) {
+	columnSet := make(map[string]struct{}, len(q.ValuesBatch))
+	for i := range q.ValuesBatch {
+		for column := range q.ValuesBatch[i] {
+			columnSet[column] = struct{}{}
+		}
+	}
+	columns := make([]string, 0, len(columnSet))
+	for column := range columnSet {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	sb := make([]byte, 0, consts.StringBuffer_Long_Query_Grow)
+	values := make([]interface{}, 0, len(q.ValuesBatch)*len(columns))
+
+	sb = append(sb, "MERGE INTO "...)
+	sb = m.u.EscapeRelation(sb, q.Table)
+	sb = append(sb, " AS "...)
+	sb = m.u.EscapeReference(sb, "target")
+	sb = append(sb, " USING (VALUES "...)
+	for i, row := range q.ValuesBatch {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = append(sb, "("...)
+		for j, column := range columns {
+			if j > 0 {
+				sb = append(sb, ", "...)
+			}
+			var err error
+			sb, values, err = appendValue(sb, m.u, row[column], values)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+		sb = append(sb, ")"...)
+	}
+	sb = append(sb, ") AS "...)
+	sb = m.u.EscapeReference(sb, "source")
+	sb = append(sb, " ("...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = m.u.EscapeReference(sb, column)
+	}
+
+	sb = append(sb, ") ON "...)
+	for i, column := range q.Upsert.UniqueColumns {
+		if i > 0 {
+			sb = append(sb, " AND "...)
+		}
+		sb = m.u.EscapeReference(sb, "target."+column)
+		sb = append(sb, " = "...)
+		sb = m.u.EscapeReference(sb, "source."+column)
+	}
+
+	if len(q.Upsert.UpdateColumns) > 0 || len(q.Upsert.UpdateValues) > 0 {
+		sb = append(sb, " WHEN MATCHED THEN UPDATE SET "...)
+		for i, column := range q.Upsert.UpdateColumns {
+			if i > 0 {
+				sb = append(sb, ", "...)
+			}
+			sb = m.u.EscapeReference(sb, "target."+column)
+			sb = append(sb, " = "...)
+			sb = m.u.EscapeReference(sb, "source."+column)
+		}
+		target := func(sb []byte, column string) []byte {
+			return m.u.EscapeReference(sb, "target."+column)
+		}
+		var err error
+		sb, values, err = appendUpsertValues(sb, m.u, q.Upsert.UpdateValues, len(q.Upsert.UpdateColumns) == 0, target, values)
+		if err != nil {
+			return "", nil, err
+		}
 	}
 
+	sb = append(sb, " WHEN NOT MATCHED THEN INSERT ("...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = m.u.EscapeReference(sb, column)
+	}
+	sb = append(sb, ") VALUES ("...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = m.u.EscapeReference(sb, "source."+column)
+	}
+	sb = append(sb, ")"...)
+	sb = appendOutput(sb, m.u, "INSERTED", q.Returning)
+	// MERGE must be terminated by a semicolon.
+	sb = append(sb, ";"...)
+
 	return string(sb), values, nil
 }
 
 // BuildInsert builds the INSERT query.
 func (m InsertBaseBuilder) BuildInsert(q *structs.InsertQuery) (string, []interface{}, error) {
+	if err := checkReturning(m.u, q.Returning); err != nil {
+		return "", nil, err
+	}
+
+	query, values, err := m.buildInsert(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// RETURNING
+	// It follows ON CONFLICT, so it is added once the statement is complete.
+	if len(q.Returning) > 0 {
+		query = string(appendReturning([]byte(query), m.u, q.Returning))
+	}
+
+	return query, values, nil
+}
+
+func (m InsertBaseBuilder) buildInsert(q *structs.InsertQuery) (string, []interface{}, error) {
 	if q.Upsert != nil {
 		return m.Upsert(q)
 	}
@@ -302,12 +459,7 @@ func (m InsertBaseBuilder) BuildInsert(q *structs.InsertQuery) (string, []interf
 			if err != nil {
 				return "", nil, err
 			}
-			if m.u.Dialect() == consts.DialectMySQL {
-				query = strings.Replace(query, "INSERT INTO", "INSERT IGNORE INTO", 1)
-			} else if m.u.Dialect() == consts.DialectPostgreSQL {
-				query += " ON CONFLICT DO NOTHING"
-			}
-			return query, values, nil
+			return m.applyIgnore(query), values, nil
 		}
 		return m.InsertIgnore(q)
 	}
@@ -0,0 +1,61 @@
+package base
+
+import (
+	"errors"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrReturningNotSupported is returned when a RETURNING clause is requested on
+// a dialect that cannot return the affected rows.
+var ErrReturningNotSupported = errors.New("returning is not supported by this dialect")
+
+// checkReturning reports an error instead of silently dropping the clause.
+func checkReturning(u interfaces.SQLUtils, columns []string) error {
+	if len(columns) > 0 && u.Dialect() == consts.DialectMySQL {
+		return ErrReturningNotSupported
+	}
+	return nil
+}
+
+// usesOutputClause reports whether the dialect returns rows with an OUTPUT
+// clause in the middle of the statement instead of a trailing RETURNING.
+func usesOutputClause(u interfaces.SQLUtils) bool {
+	return u.Dialect() == consts.DialectSQLServer
+}
+
+// appendReturning renders a trailing RETURNING clause.
+func appendReturning(sb []byte, u interfaces.SQLUtils, columns []string) []byte {
+	if len(columns) == 0 || usesOutputClause(u) {
+		return sb
+	}
+
+	sb = append(sb, " RETURNING "...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = u.EscapeReference(sb, column)
+	}
+	return sb
+}
+
+// appendOutput renders SQL Server's OUTPUT clause reading from the INSERTED or
+// DELETED pseudo table.
+func appendOutput(sb []byte, u interfaces.SQLUtils, pseudoTable string, columns []string) []byte {
+	if len(columns) == 0 || !usesOutputClause(u) {
+		return sb
+	}
+
+	sb = append(sb, " OUTPUT "...)
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = append(sb, pseudoTable...)
+		sb = append(sb, '.')
+		sb = u.EscapeReference(sb, column)
+	}
+	return sb
+}
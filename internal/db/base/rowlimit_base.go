@@ -0,0 +1,106 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrLimitNotSupported is returned for a row limit of an UPDATE or DELETE the
+// dialect cannot express.
+var ErrLimitNotSupported = errors.New("limit is not supported in this statement by this dialect")
+
+// rowLimit is how an UPDATE or DELETE limits the rows it changes.
+type rowLimit struct {
+	groups []structs.WhereGroup
+	order  *[]structs.Order
+	// top is rendered as TOP (n) after the keyword of the statement.
+	top int64
+	// limit is rendered as a trailing LIMIT n.
+	limit int64
+}
+
+// limitRows returns the row limit of an UPDATE or DELETE of table built from
+// q. MySQL limits the rows natively and SQL Server with TOP when there is no
+// order. Otherwise the rows are matched by key in a subquery: the WHERE
+// clause, order and limit move into it. key defaults to ctid on PostgreSQL
+// and rowid on SQLite. joined reports whether the statement reads other
+// tables, which none of the dialects can combine with a limit.
+func limitRows(u interfaces.SQLUtils, table string, q *structs.Query, key string, joined bool) (rowLimit, error) {
+	rl := rowLimit{groups: q.ConditionGroups, order: q.Order}
+	n := q.Limit.Limit
+	if n == 0 {
+		return rl, nil
+	}
+	if joined {
+		return rowLimit{}, fmt.Errorf("%w: LIMIT with joined tables on %s", ErrLimitNotSupported, u.Dialect())
+	}
+
+	switch u.Dialect() {
+	case consts.DialectPostgreSQL:
+		if key == "" {
+			key = "ctid"
+		}
+	case consts.DialectSQLite:
+		if key == "" {
+			key = "rowid"
+		}
+	case consts.DialectSQLServer:
+		ordered := q.Order != nil && len(*q.Order) > 0
+		if !ordered {
+			rl.top = n
+			return rl, nil
+		}
+		if key == "" {
+			return rowLimit{}, fmt.Errorf("%w: LIMIT with ORDER BY needs a limit key on %s", ErrLimitNotSupported, u.Dialect())
+		}
+	default:
+		rl.limit = n
+		return rl, nil
+	}
+
+	sub := &structs.Query{
+		Columns:         &[]structs.Column{{Name: key}},
+		Table:           structs.Table{Name: table},
+		ConditionGroups: q.ConditionGroups,
+		Conditions:      &[]structs.Where{},
+		Joins:           &structs.Joins{},
+		Order:           q.Order,
+		Limit:           q.Limit,
+	}
+	rl.groups = []structs.WhereGroup{{
+		Conditions: []structs.Where{{
+			Column:    key,
+			Condition: consts.Condition_IN,
+			Query:     sub,
+			Operator:  consts.LogicalOperator_AND,
+		}},
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	}}
+	rl.order = nil
+	return rl, nil
+}
+
+// appendTop appends the TOP clause of rl.
+func (rl rowLimit) appendTop(sb []byte) []byte {
+	if rl.top == 0 {
+		return sb
+	}
+	sb = append(sb, " TOP ("...)
+	sb = strconv.AppendInt(sb, rl.top, 10)
+	return append(sb, ')')
+}
+
+// appendLimit appends the LIMIT clause of rl.
+func (rl rowLimit) appendLimit(sb []byte) []byte {
+	if rl.limit == 0 {
+		return sb
+	}
+	sb = append(sb, " LIMIT "...)
+	return strconv.AppendInt(sb, rl.limit, 10)
+}
@@ -0,0 +1,598 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrSchemaUnsupported is returned for DDL the dialect cannot express, such as
+// adding a foreign key to an existing SQLite table.
+var ErrSchemaUnsupported = errors.New("schema operation is not supported by this dialect")
+
+type SchemaBaseBuilder struct {
+	u interfaces.SQLUtils
+}
+
+func NewSchemaBaseBuilder(u interfaces.SQLUtils) *SchemaBaseBuilder {
+	return &SchemaBaseBuilder{
+		u: u,
+	}
+}
+
+// BuildSchema renders a DDL command as one or more statements.
+func (m SchemaBaseBuilder) BuildSchema(q *structs.SchemaQuery) ([]string, error) {
+	switch q.Command {
+	case consts.Schema_CREATE, consts.Schema_CREATE_IF_NOT_EXISTS:
+		return m.createTable(&q.Table, q.Command == consts.Schema_CREATE_IF_NOT_EXISTS)
+	case consts.Schema_ALTER:
+		return m.alterTable(&q.Table)
+	case consts.Schema_DROP, consts.Schema_DROP_IF_EXISTS:
+		sb := []byte("DROP TABLE ")
+		if q.Command == consts.Schema_DROP_IF_EXISTS {
+			sb = append(sb, "IF EXISTS "...)
+		}
+		sb = m.u.EscapeRelation(sb, q.Table.Name)
+		return []string{string(sb)}, nil
+	case consts.Schema_RENAME:
+		return []string{m.renameTable(q.Table.Name, q.To)}, nil
+	}
+
+	return nil, fmt.Errorf("unknown schema command %q", q.Command)
+}
+
+func (m SchemaBaseBuilder) createTable(t *structs.SchemaTable, ifNotExists bool) ([]string, error) {
+	sb := make([]byte, 0, consts.StringBuffer_Middle_Query_Grow)
+
+	if ifNotExists && m.u.Dialect() == consts.DialectSQLServer {
+		sb = append(sb, "IF OBJECT_ID("...)
+		sb = appendStringLiteral(sb, t.Name, false)
+		sb = append(sb, ", 'U') IS NULL "...)
+	}
+	sb = append(sb, "CREATE TABLE "...)
+	if ifNotExists && m.u.Dialect() != consts.DialectSQLServer {
+		sb = append(sb, "IF NOT EXISTS "...)
+	}
+	sb = m.u.EscapeRelation(sb, t.Name)
+	sb = append(sb, " ("...)
+
+	primary := make([]string, 0)
+	for i := range t.Columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		var err error
+		sb, err = m.appendColumn(sb, &t.Columns[i])
+		if err != nil {
+			return nil, err
+		}
+		if t.Columns[i].Primary && !isIncrements(t.Columns[i].Type) {
+			primary = append(primary, t.Columns[i].Name)
+		}
+	}
+
+	for _, idx := range t.Indexes {
+		if idx.Type == consts.Index_PRIMARY {
+			primary = append(primary, idx.Columns...)
+		}
+	}
+	if len(primary) > 0 {
+		sb = append(sb, ", PRIMARY KEY ("...)
+		sb = m.appendColumnList(sb, primary)
+		sb = append(sb, ')')
+	}
+
+	for i := range t.ForeignKeys {
+		sb = append(sb, ", "...)
+		var err error
+		sb, err = m.appendForeignKey(sb, t.Name, &t.ForeignKeys[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	sb = append(sb, ')')
+
+	statements := []string{string(sb)}
+	for i := range t.Indexes {
+		if t.Indexes[i].Type == consts.Index_PRIMARY {
+			continue
+		}
+		stmt, err := m.createIndex(t.Name, &t.Indexes[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+
+	return statements, nil
+}
+
+func (m SchemaBaseBuilder) alterTable(t *structs.SchemaTable) ([]string, error) {
+	statements := make([]string, 0)
+	alter := func() []byte {
+		sb := make([]byte, 0, consts.StringBuffer_Short_Query_Grow)
+		sb = append(sb, "ALTER TABLE "...)
+		return m.u.EscapeRelation(sb, t.Name)
+	}
+
+	// DROP FOREIGN KEY
+	for _, name := range t.DropForeignKeys {
+		sb := alter()
+		switch m.u.Dialect() {
+		case consts.DialectMySQL:
+			sb = append(sb, " DROP FOREIGN KEY "...)
+		case consts.DialectSQLite:
+			return nil, fmt.Errorf("%w: drop foreign key", ErrSchemaUnsupported)
+		default:
+			sb = append(sb, " DROP CONSTRAINT "...)
+		}
+		sb = m.u.EscapeReference(sb, name)
+		statements = append(statements, string(sb))
+	}
+
+	// DROP INDEX
+	for _, name := range t.DropIndexes {
+		sb := []byte("DROP INDEX ")
+		sb = m.u.EscapeReference(sb, name)
+		if m.u.Dialect() == consts.DialectMySQL || m.u.Dialect() == consts.DialectSQLServer {
+			sb = append(sb, " ON "...)
+			sb = m.u.EscapeRelation(sb, t.Name)
+		}
+		statements = append(statements, string(sb))
+	}
+
+	// RENAME COLUMN
+	for _, rc := range t.RenameColumns {
+		if m.u.Dialect() == consts.DialectSQLServer {
+			sb := []byte("EXEC sp_rename ")
+			sb = appendStringLiteral(sb, t.Name+"."+rc.From, false)
+			sb = append(sb, ", "...)
+			sb = appendStringLiteral(sb, rc.To, false)
+			sb = append(sb, ", 'COLUMN'"...)
+			statements = append(statements, string(sb))
+			continue
+		}
+		sb := alter()
+		sb = append(sb, " RENAME COLUMN "...)
+		sb = m.u.EscapeReference(sb, rc.From)
+		sb = append(sb, " TO "...)
+		sb = m.u.EscapeReference(sb, rc.To)
+		statements = append(statements, string(sb))
+	}
+
+	// DROP COLUMN
+	for _, name := range t.DropColumns {
+		sb := alter()
+		sb = append(sb, " DROP COLUMN "...)
+		sb = m.u.EscapeReference(sb, name)
+		statements = append(statements, string(sb))
+	}
+
+	// ADD COLUMN
+	primary := make([]string, 0)
+	for i := range t.Columns {
+		sb := alter()
+		if m.u.Dialect() == consts.DialectSQLServer {
+			sb = append(sb, " ADD "...)
+		} else {
+			sb = append(sb, " ADD COLUMN "...)
+		}
+		var err error
+		sb, err = m.appendColumn(sb, &t.Columns[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, string(sb))
+		if t.Columns[i].Primary && !isIncrements(t.Columns[i].Type) {
+			primary = append(primary, t.Columns[i].Name)
+		}
+	}
+
+	// PRIMARY KEY
+	for _, idx := range t.Indexes {
+		if idx.Type == consts.Index_PRIMARY {
+			primary = append(primary, idx.Columns...)
+		}
+	}
+	if len(primary) > 0 {
+		if m.u.Dialect() == consts.DialectSQLite {
+			return nil, fmt.Errorf("%w: add primary key", ErrSchemaUnsupported)
+		}
+		sb := alter()
+		sb = append(sb, " ADD PRIMARY KEY ("...)
+		sb = m.appendColumnList(sb, primary)
+		sb = append(sb, ')')
+		statements = append(statements, string(sb))
+	}
+
+	// INDEX
+	for i := range t.Indexes {
+		if t.Indexes[i].Type == consts.Index_PRIMARY {
+			continue
+		}
+		stmt, err := m.createIndex(t.Name, &t.Indexes[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+
+	// FOREIGN KEY
+	for i := range t.ForeignKeys {
+		if m.u.Dialect() == consts.DialectSQLite {
+			return nil, fmt.Errorf("%w: add foreign key", ErrSchemaUnsupported)
+		}
+		sb := alter()
+		sb = append(sb, " ADD "...)
+		var err error
+		sb, err = m.appendForeignKey(sb, t.Name, &t.ForeignKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, string(sb))
+	}
+
+	return statements, nil
+}
+
+func (m SchemaBaseBuilder) renameTable(from, to string) string {
+	switch m.u.Dialect() {
+	case consts.DialectMySQL:
+		sb := []byte("RENAME TABLE ")
+		sb = m.u.EscapeRelation(sb, from)
+		sb = append(sb, " TO "...)
+		sb = m.u.EscapeRelation(sb, to)
+		return string(sb)
+	case consts.DialectSQLServer:
+		sb := []byte("EXEC sp_rename ")
+		sb = appendStringLiteral(sb, from, false)
+		sb = append(sb, ", "...)
+		sb = appendStringLiteral(sb, to, false)
+		return string(sb)
+	}
+
+	sb := []byte("ALTER TABLE ")
+	sb = m.u.EscapeRelation(sb, from)
+	sb = append(sb, " RENAME TO "...)
+	sb = m.u.EscapeRelation(sb, to)
+	return string(sb)
+}
+
+func (m SchemaBaseBuilder) createIndex(table string, idx *structs.SchemaIndex) (string, error) {
+	sb := make([]byte, 0, consts.StringBuffer_Short_Query_Grow)
+
+	switch idx.Type {
+	case consts.Index_UNIQUE:
+		sb = append(sb, "CREATE UNIQUE INDEX "...)
+	case consts.Index_FULLTEXT:
+		switch m.u.Dialect() {
+		case consts.DialectMySQL:
+			sb = append(sb, "CREATE FULLTEXT INDEX "...)
+		case consts.DialectPostgreSQL:
+			sb = append(sb, "CREATE INDEX "...)
+			sb = m.u.EscapeReference(sb, idx.Name)
+			sb = append(sb, " ON "...)
+			sb = m.u.EscapeRelation(sb, table)
+			// the same expression as the full text WHERE condition
+			sb = append(sb, " USING GIN (("...)
+			for i, column := range idx.Columns {
+				if i > 0 {
+					sb = append(sb, " || "...)
+				}
+				sb = append(sb, "to_tsvector('english', "...)
+				sb = m.u.EscapeReference(sb, column)
+				sb = append(sb, ')')
+			}
+			sb = append(sb, "))"...)
+			return string(sb), nil
+		default:
+			return "", fmt.Errorf("%w: fulltext index", ErrSchemaUnsupported)
+		}
+	default:
+		sb = append(sb, "CREATE INDEX "...)
+	}
+
+	sb = m.u.EscapeReference(sb, idx.Name)
+	sb = append(sb, " ON "...)
+	sb = m.u.EscapeRelation(sb, table)
+	sb = append(sb, " ("...)
+	sb = m.appendColumnList(sb, idx.Columns)
+	sb = append(sb, ')')
+
+	return string(sb), nil
+}
+
+func (m SchemaBaseBuilder) appendForeignKey(sb []byte, table string, fk *structs.ForeignKey) ([]byte, error) {
+	if fk.RefTable == "" || len(fk.RefColumns) == 0 {
+		return nil, fmt.Errorf("foreign key %q has no referenced table or columns", fk.Name)
+	}
+
+	sb = append(sb, "CONSTRAINT "...)
+	sb = m.u.EscapeReference(sb, fk.Name)
+	sb = append(sb, " FOREIGN KEY ("...)
+	sb = m.appendColumnList(sb, fk.Columns)
+	sb = append(sb, ") REFERENCES "...)
+	sb = m.u.EscapeRelation(sb, fk.RefTable)
+	sb = append(sb, " ("...)
+	sb = m.appendColumnList(sb, fk.RefColumns)
+	sb = append(sb, ')')
+
+	for _, action := range []struct{ clause, value string }{{" ON DELETE ", fk.OnDelete}, {" ON UPDATE ", fk.OnUpdate}} {
+		if action.value == "" {
+			continue
+		}
+		value := strings.ToUpper(action.value)
+		switch value {
+		case "CASCADE", "SET NULL", "SET DEFAULT", "RESTRICT", "NO ACTION":
+		default:
+			return nil, fmt.Errorf("invalid foreign key action %q", action.value)
+		}
+		sb = append(sb, action.clause...)
+		sb = append(sb, value...)
+	}
+
+	return sb, nil
+}
+
+func (m SchemaBaseBuilder) appendColumnList(sb []byte, columns []string) []byte {
+	for i, column := range columns {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = m.u.EscapeReference(sb, column)
+	}
+	return sb
+}
+
+// appendColumn renders a column definition.
+func (m SchemaBaseBuilder) appendColumn(sb []byte, c *structs.SchemaColumn) ([]byte, error) {
+	sb = m.u.EscapeReference(sb, c.Name)
+	sb = append(sb, ' ')
+
+	if isIncrements(c.Type) {
+		return append(sb, m.incrementsType(c.Type == consts.ColumnType_BIG_INCREMENTS)...), nil
+	}
+
+	typ, err := m.columnType(c)
+	if err != nil {
+		return nil, err
+	}
+	sb = append(sb, typ...)
+
+	if c.Nullable {
+		sb = append(sb, " NULL"...)
+	} else {
+		sb = append(sb, " NOT NULL"...)
+	}
+
+	if c.DefaultRaw != "" {
+		sb = append(sb, " DEFAULT "...)
+		sb = append(sb, c.DefaultRaw...)
+	} else if c.HasDefault {
+		sb = append(sb, " DEFAULT "...)
+		sb, err = m.appendLiteral(sb, c.Default)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// enums are a CHECK constraint outside of MySQL
+	if c.Type == consts.ColumnType_ENUM && m.u.Dialect() != consts.DialectMySQL {
+		sb = append(sb, " CHECK ("...)
+		sb = m.u.EscapeReference(sb, c.Name)
+		sb = append(sb, " IN ("...)
+		sb = m.appendStringList(sb, c.Values)
+		sb = append(sb, "))"...)
+	}
+
+	return sb, nil
+}
+
+func (m SchemaBaseBuilder) incrementsType(big bool) string {
+	switch m.u.Dialect() {
+	case consts.DialectMySQL:
+		if big {
+			return "BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY"
+		}
+		return "INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY"
+	case consts.DialectPostgreSQL:
+		if big {
+			return "BIGSERIAL PRIMARY KEY"
+		}
+		return "SERIAL PRIMARY KEY"
+	case consts.DialectSQLite:
+		return "INTEGER PRIMARY KEY AUTOINCREMENT"
+	case consts.DialectSQLServer:
+		if big {
+			return "BIGINT IDENTITY(1,1) NOT NULL PRIMARY KEY"
+		}
+		return "INT IDENTITY(1,1) NOT NULL PRIMARY KEY"
+	}
+
+	if big {
+		return "BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+	}
+	return "INTEGER GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY"
+}
+
+func (m SchemaBaseBuilder) columnType(c *structs.SchemaColumn) (string, error) {
+	d := m.u.Dialect()
+
+	switch c.Type {
+	case consts.ColumnType_INTEGER:
+		switch d {
+		case consts.DialectMySQL:
+			if c.Unsigned {
+				return "INT UNSIGNED", nil
+			}
+			return "INT", nil
+		case consts.DialectSQLServer:
+			return "INT", nil
+		}
+		return "INTEGER", nil
+	case consts.ColumnType_BIG_INTEGER:
+		if d == consts.DialectSQLite {
+			return "INTEGER", nil
+		}
+		if d == consts.DialectMySQL && c.Unsigned {
+			return "BIGINT UNSIGNED", nil
+		}
+		return "BIGINT", nil
+	case consts.ColumnType_BOOLEAN:
+		switch d {
+		case consts.DialectMySQL:
+			return "TINYINT(1)", nil
+		case consts.DialectSQLite:
+			return "INTEGER", nil
+		case consts.DialectSQLServer:
+			return "BIT", nil
+		}
+		return "BOOLEAN", nil
+	case consts.ColumnType_STRING:
+		length := strconv.Itoa(c.Length)
+		if d == consts.DialectSQLServer {
+			return "NVARCHAR(" + length + ")", nil
+		}
+		return "VARCHAR(" + length + ")", nil
+	case consts.ColumnType_TEXT:
+		if d == consts.DialectSQLServer {
+			return "NVARCHAR(MAX)", nil
+		}
+		return "TEXT", nil
+	case consts.ColumnType_JSON, consts.ColumnType_JSONB:
+		switch d {
+		case consts.DialectPostgreSQL:
+			if c.Type == consts.ColumnType_JSONB {
+				return "JSONB", nil
+			}
+			return "JSON", nil
+		case consts.DialectSQLite:
+			return "TEXT", nil
+		case consts.DialectSQLServer:
+			return "NVARCHAR(MAX)", nil
+		}
+		return "JSON", nil
+	case consts.ColumnType_DATE:
+		return "DATE", nil
+	case consts.ColumnType_TIMESTAMP:
+		switch d {
+		case consts.DialectSQLite:
+			return "DATETIME", nil
+		case consts.DialectSQLServer:
+			return "DATETIME2", nil
+		}
+		return "TIMESTAMP", nil
+	case consts.ColumnType_TIMESTAMP_TZ:
+		switch d {
+		case consts.DialectMySQL:
+			// MySQL stores TIMESTAMP in UTC and converts with the session time zone
+			return "TIMESTAMP", nil
+		case consts.DialectSQLite:
+			return "DATETIME", nil
+		case consts.DialectSQLServer:
+			return "DATETIMEOFFSET", nil
+		}
+		return "TIMESTAMP WITH TIME ZONE", nil
+	case consts.ColumnType_DECIMAL:
+		return "DECIMAL(" + strconv.Itoa(c.Precision) + ", " + strconv.Itoa(c.Scale) + ")", nil
+	case consts.ColumnType_UUID:
+		switch d {
+		case consts.DialectMySQL:
+			return "CHAR(36)", nil
+		case consts.DialectPostgreSQL:
+			return "UUID", nil
+		case consts.DialectSQLServer:
+			return "UNIQUEIDENTIFIER", nil
+		}
+		return "VARCHAR(36)", nil
+	case consts.ColumnType_ENUM:
+		if len(c.Values) == 0 {
+			return "", fmt.Errorf("enum column %q has no values", c.Name)
+		}
+		switch d {
+		case consts.DialectMySQL:
+			return string(m.appendStringList([]byte("ENUM("), c.Values)) + ")", nil
+		case consts.DialectSQLServer:
+			return "NVARCHAR(255)", nil
+		}
+		return "VARCHAR(255)", nil
+	}
+
+	return "", fmt.Errorf("unknown column type %q for column %q", c.Type, c.Name)
+}
+
+func (m SchemaBaseBuilder) appendStringList(sb []byte, values []string) []byte {
+	for i, v := range values {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		sb = appendStringLiteral(sb, v, m.u.Dialect() == consts.DialectMySQL)
+	}
+	return sb
+}
+
+// appendLiteral renders a DEFAULT value.
+func (m SchemaBaseBuilder) appendLiteral(sb []byte, v interface{}) ([]byte, error) {
+	switch val := v.(type) {
+	case nil:
+		return append(sb, "NULL"...), nil
+	case string:
+		return appendStringLiteral(sb, val, m.u.Dialect() == consts.DialectMySQL), nil
+	case bool:
+		if m.u.Dialect() == consts.DialectPostgreSQL || m.u.Dialect() == consts.DialectBase {
+			if val {
+				return append(sb, "TRUE"...), nil
+			}
+			return append(sb, "FALSE"...), nil
+		}
+		if val {
+			return append(sb, '1'), nil
+		}
+		return append(sb, '0'), nil
+	case int:
+		return strconv.AppendInt(sb, int64(val), 10), nil
+	case int32:
+		return strconv.AppendInt(sb, int64(val), 10), nil
+	case int64:
+		return strconv.AppendInt(sb, val, 10), nil
+	case uint:
+		return strconv.AppendUint(sb, uint64(val), 10), nil
+	case uint64:
+		return strconv.AppendUint(sb, val, 10), nil
+	case float32:
+		return strconv.AppendFloat(sb, float64(val), 'f', -1, 32), nil
+	case float64:
+		return strconv.AppendFloat(sb, val, 'f', -1, 64), nil
+	}
+
+	return nil, fmt.Errorf("unsupported default value of type %T", v)
+}
+
+// appendStringLiteral quotes a string literal. MySQL also treats backslash as
+// an escape character.
+func appendStringLiteral(sb []byte, v string, escapeBackslash bool) []byte {
+	sb = append(sb, '\'')
+	for i := 0; i < len(v); i++ {
+		switch v[i] {
+		case '\'':
+			sb = append(sb, "''"...)
+		case '\\':
+			if escapeBackslash {
+				sb = append(sb, '\\')
+			}
+			sb = append(sb, '\\')
+		default:
+			sb = append(sb, v[i])
+		}
+	}
+	return append(sb, '\'')
+}
+
+func isIncrements(t string) bool {
+	return t == consts.ColumnType_INCREMENTS || t == consts.ColumnType_BIG_INCREMENTS
+}
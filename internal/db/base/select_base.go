@@ -9,14 +9,15 @@ import (
 )
 
 type SelectBaseBuilder struct {
-	columnNames *[]string
-	u           interfaces.SQLUtils
+	u interfaces.SQLUtils
 }
 
+// NewSelectBaseBuilder returns a select renderer. columnNames is not used: the
+// tables selected for the joins are tracked per statement, as the renderer is
+// shared between goroutines.
 func NewSelectBaseBuilder(u interfaces.SQLUtils, columnNames *[]string) *SelectBaseBuilder {
 	return &SelectBaseBuilder{
-		columnNames: columnNames,
-		u:           u,
+		u: u,
 	}
 }
 
@@ -36,8 +37,9 @@ func (b *SelectBaseBuilder) Select(sb *[]byte, columns *[]structs.Column, tableN
 		if joins.Joins != nil {
 			sortedJoins = append(sortedJoins, (*joins.Joins)...)
 		}
+		selected := make([]string, 0, len(sortedJoins)+1)
 		for i, join := range sortedJoins {
-			b.processJoin(sb, &join, tableName, i)
+			b.processJoin(sb, &join, tableName, i, &selected)
 			outputed = true
 		}
 
@@ -47,7 +49,7 @@ func (b *SelectBaseBuilder) Select(sb *[]byte, columns *[]structs.Column, tableN
 					TargetNameMap: joinClause.TargetNameMap,
 					Name:          joinClause.Name,
 				}
-				b.processJoin(sb, &join, tableName, 0)
+				b.processJoin(sb, &join, tableName, 0, &selected)
 				outputed = true
 			}
 		}
@@ -98,7 +100,20 @@ func (b *SelectBaseBuilder) Select(sb *[]byte, columns *[]structs.Column, tableN
 			continue
 		}
 
-		if (*columns)[i].Function != "" {
+		if (*columns)[i].Window != nil {
+			if i > 0 {
+				*sb = append(*sb, ", "...)
+			}
+			out, err := appendWindowFunction(*sb, b.u, (*columns)[i].Window)
+			if err != nil {
+				return nil, err
+			}
+			*sb = out
+			if (*columns)[i].Name != "" {
+				*sb = append(*sb, " as "...)
+				*sb = b.u.EscapeReference(*sb, (*columns)[i].Name)
+			}
+		} else if (*columns)[i].Function != "" {
 			if i > 0 {
 				*sb = append(*sb, ", "...)
 			}
@@ -141,7 +156,9 @@ func (b *SelectBaseBuilder) Select(sb *[]byte, columns *[]structs.Column, tableN
 	return colValues, nil
 }
 
-func (j *SelectBaseBuilder) processJoin(sb *[]byte, join *structs.Join, tableName string, idx int) {
+// processJoin selects the columns of the tables of join which are not in
+// selected yet.
+func (j *SelectBaseBuilder) processJoin(sb *[]byte, join *structs.Join, tableName string, idx int, selected *[]string) {
 	targetName := ""
 	//joinedTablesForSelect := ""
 
@@ -181,12 +198,12 @@ func (j *SelectBaseBuilder) processJoin(sb *[]byte, join *structs.Join, tableNam
 	wsb = wsb[:0]
 
 	outputed := false
-	if !sliceutils.Contains(*j.columnNames, targetNameForSelect) {
+	if !sliceutils.Contains(*selected, targetNameForSelect) {
 		if idx > 0 {
 			*sb = append(*sb, ", "...)
 		}
 		*sb = append(*sb, targetNameForSelect...)
-		*j.columnNames = append(*j.columnNames, targetNameForSelect)
+		*selected = append(*selected, targetNameForSelect)
 		outputed = true
 	}
 
@@ -195,12 +212,12 @@ func (j *SelectBaseBuilder) processJoin(sb *[]byte, join *structs.Join, tableNam
 	wsb = append(wsb, ".*"...)
 	nameForSelect := string(wsb)
 
-	if !sliceutils.Contains(*j.columnNames, nameForSelect) {
+	if !sliceutils.Contains(*selected, nameForSelect) {
 		if idx > 0 || outputed {
 			*sb = append(*sb, ", "...)
 		}
 		*sb = append(*sb, nameForSelect...)
-		*j.columnNames = append(*j.columnNames, nameForSelect)
+		*selected = append(*selected, nameForSelect)
 	}
 
 }
@@ -0,0 +1,166 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrJoinNotSupported is returned for a join the dialect cannot express in an
+// UPDATE or DELETE.
+var ErrJoinNotSupported = errors.New("join is not supported in this statement by this dialect")
+
+// hasJoins reports whether joins holds a join of any kind.
+func hasJoins(joins *structs.Joins) bool {
+	if joins == nil {
+		return false
+	}
+	return (joins.Joins != nil && len(*joins.Joins) > 0) ||
+		(joins.JoinClauses != nil && len(*joins.JoinClauses) > 0) ||
+		(joins.LateralJoins != nil && len(*joins.LateralJoins) > 0)
+}
+
+// appendSources appends sources separated by commas. A subquery is rendered
+// in parentheses followed by its name.
+func appendSources(sb []byte, u interfaces.SQLUtils, sources []structs.Source, values []interface{}) ([]byte, []interface{}, error) {
+	for i, s := range sources {
+		if i > 0 {
+			sb = append(sb, ", "...)
+		}
+		if s.Query == nil {
+			sb = u.EscapeRelation(sb, s.Name)
+			continue
+		}
+
+		sb = append(sb, '(')
+		v, err := u.GetQueryBuilderStrategy().Build(&sb, s.Query, 0, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = append(values, v...)
+		sb = append(sb, ") AS "...)
+		sb = u.EscapeReference(sb, s.Name)
+	}
+	return sb, values, nil
+}
+
+// joinSources turns joins into sources and their ON conditions into WHERE
+// conditions, for the dialects that list the other tables of an UPDATE or
+// DELETE in a FROM or USING clause. Only inner and cross joins keep their
+// meaning there.
+func joinSources(u interfaces.SQLUtils, joins *structs.Joins) ([]structs.Source, []structs.Where, error) {
+	if !hasJoins(joins) {
+		return nil, nil, nil
+	}
+
+	jb := NewJoinBaseBuilder(u, joins)
+	var sources []structs.Source
+	var on []structs.Where
+	add := func(conditions []structs.Where) {
+		if len(conditions) == 0 {
+			return
+		}
+		if structs.HasTopLevelOr([]structs.WhereGroup{{Conditions: conditions, IsDummyGroup: true}}) {
+			on = append(on, structs.Where{
+				Nested:   []structs.WhereGroup{{Conditions: conditions, IsDummyGroup: true}},
+				Operator: consts.LogicalOperator_AND,
+			})
+			return
+		}
+		for _, c := range conditions {
+			c.Operator = consts.LogicalOperator_AND
+			on = append(on, c)
+		}
+	}
+
+	if joins.JoinClauses != nil {
+		for _, jc := range *joins.JoinClauses {
+			joinType, target := jb.processJoin(&structs.Join{TargetNameMap: jc.TargetNameMap})
+			if joinType != consts.Join_Type_INNER && joinType != consts.Join_Type_CROSS {
+				return nil, nil, fmt.Errorf("%w: %s JOIN on %s", ErrJoinNotSupported, joinType, u.Dialect())
+			}
+			sources = append(sources, structs.Source{Name: target, Query: jc.Query})
+
+			var conditions []structs.Where
+			if jc.On != nil {
+				for _, o := range *jc.On {
+					c := structs.Where{Column: o.Column, Condition: o.Condition, Operator: o.Operator}
+					if column, ok := o.Value.(string); ok {
+						c.ValueColumn = column
+					} else if o.Value != nil {
+						c.Value = []interface{}{o.Value}
+					}
+					conditions = append(conditions, c)
+				}
+			}
+			if jc.Conditions != nil {
+				conditions = append(conditions, *jc.Conditions...)
+			}
+			add(conditions)
+		}
+	}
+
+	var all []structs.Join
+	if joins.LateralJoins != nil {
+		all = append(all, *joins.LateralJoins...)
+	}
+	if joins.Joins != nil {
+		all = append(all, *joins.Joins...)
+	}
+	for _, j := range all {
+		joinType, target := jb.processJoin(&j)
+		if joinType == "" {
+			continue
+		}
+		if joinType != consts.Join_Type_INNER && joinType != consts.Join_Type_CROSS {
+			return nil, nil, fmt.Errorf("%w: %s JOIN on %s", ErrJoinNotSupported, joinType, u.Dialect())
+		}
+		sources = append(sources, structs.Source{Name: target, Query: j.Query})
+		if joinType == consts.Join_Type_INNER {
+			add([]structs.Where{{
+				Column:      j.SearchColumn,
+				Condition:   j.SearchCondition,
+				ValueColumn: j.SearchTargetColumn,
+			}})
+		}
+	}
+
+	return sources, on, nil
+}
+
+// joinWhere returns the WHERE groups of a statement whose join conditions on
+// were moved into the WHERE clause, in front of groups.
+func joinWhere(on []structs.Where, groups []structs.WhereGroup) []structs.WhereGroup {
+	if len(on) == 0 {
+		return groups
+	}
+
+	result := []structs.WhereGroup{{
+		Conditions:   on,
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	}}
+	if !structs.HasConditions(groups) {
+		return result
+	}
+	if structs.HasTopLevelOr(groups) {
+		return append(result, structs.NestedGroup(groups))
+	}
+
+	// without a top level OR the leading operator is AND, whatever was set
+	// on the first condition
+	first := true
+	for _, g := range groups {
+		if first && len(g.Conditions) > 0 {
+			first = false
+			g.Operator = consts.LogicalOperator_AND
+			g.Conditions = append([]structs.Where(nil), g.Conditions...)
+			g.Conditions[0].Operator = consts.LogicalOperator_AND
+		}
+		result = append(result, g)
+	}
+	return result
+}
@@ -1,44 +1,132 @@
 package base
 
 import (
+	"errors"
 	"sort"
 	"strings"
 
 	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/jsonutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/memutils"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/sqlutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 )
 
+// ErrJSONArithmeticNotSupported is returned for an increment or decrement of
+// a JSON path on a dialect that cannot read the value at the path as a number.
+var ErrJSONArithmeticNotSupported = errors.New("arithmetic on a JSON path is not supported by this dialect")
+
 type UpdateBaseBuilder struct {
 	u interfaces.SQLUtils
 }
 
-func formatJSONUpdateExpression(sb []byte, u interfaces.SQLUtils, field string, path []string, placeholder string) []byte {
+// formatJSONUpdateExpression appends the assignment of placeholder at path in
+// the JSON column field to target, the same column as written in SET.
+func formatJSONUpdateExpression(sb []byte, u interfaces.SQLUtils, target, field string, path []string, placeholder string) []byte {
 	switch u.Dialect() {
 	case consts.DialectMySQL:
-		sb = u.EscapeReference(sb, field)
+		sb = u.EscapeReference(sb, target)
 		sb = append(sb, " = JSON_SET("...)
 		sb = u.EscapeReference(sb, field)
 		sb = append(sb, ", '$."+strings.Join(path, ".")+"', "...)
 		sb = append(sb, placeholder...)
 		sb = append(sb, ')')
-	case consts.DialectPostgreSQL:
+	case consts.DialectSQLite:
+		sb = u.EscapeReference(sb, target)
+		sb = append(sb, " = json_set("...)
 		sb = u.EscapeReference(sb, field)
+		sb = append(sb, ", '$."+strings.Join(path, ".")+"', "...)
+		sb = append(sb, placeholder...)
+		sb = append(sb, ')')
+	case consts.DialectSQLServer:
+		sb = u.EscapeReference(sb, target)
+		sb = append(sb, " = JSON_MODIFY("...)
+		sb = u.EscapeReference(sb, field)
+		sb = append(sb, ", '$."+strings.Join(path, ".")+"', "...)
+		sb = append(sb, placeholder...)
+		sb = append(sb, ')')
+	case consts.DialectPostgreSQL:
+		sb = u.EscapeReference(sb, target)
 		sb = append(sb, " = jsonb_set("...)
 		sb = u.EscapeReference(sb, field)
 		sb = append(sb, ", '{"+strings.Join(path, ",")+"}', "...)
 		sb = append(sb, placeholder...)
 		sb = append(sb, ')')
 	default:
-		sb = u.EscapeReference(sb, field)
+		sb = u.EscapeReference(sb, target)
 		sb = append(sb, " = "...)
 		sb = append(sb, placeholder...)
 	}
 	return sb
 }
 
+// appendArithmetic appends the assignment to target, column as written in SET,
+// of the current value of column combined with a.Amount. A JSON path column is
+// updated in place where the dialect can read the value at the path as a
+// number.
+func appendArithmetic(sb []byte, u interfaces.SQLUtils, target, column string, a structs.Arithmetic, values []interface{}) ([]byte, []interface{}, error) {
+	if !strings.Contains(column, "->") {
+		sb = u.EscapeReference(sb, target)
+		sb = append(sb, " = "...)
+		sb = u.EscapeReference(sb, column)
+		sb = append(sb, " "+a.Operator+" "+u.GetPlaceholder()...)
+		return sb, append(values, a.Amount), nil
+	}
+
+	field, path := jsonutils.ParseJsonFieldAndPath(column)
+	var current []byte
+	switch u.Dialect() {
+	case consts.DialectMySQL, consts.DialectSQLite:
+		if u.Dialect() == consts.DialectMySQL {
+			current = append(current, "JSON_EXTRACT("...)
+		} else {
+			current = append(current, "json_extract("...)
+		}
+		current = u.EscapeReference(current, field)
+		current = append(current, ", '$."+strings.Join(path, ".")+"')"...)
+	case consts.DialectPostgreSQL:
+		current = append(current, "("...)
+		current = u.EscapeReference(current, field)
+		current = append(current, " #>> '{"+strings.Join(path, ",")+"}')::numeric"...)
+	default:
+		return nil, nil, ErrJSONArithmeticNotSupported
+	}
+
+	expr := string(current) + " " + a.Operator + " " + u.GetPlaceholder()
+	if u.Dialect() == consts.DialectPostgreSQL {
+		expr = "to_jsonb(" + expr + ")"
+	}
+	targetField, _ := jsonutils.ParseJsonFieldAndPath(target)
+	return formatJSONUpdateExpression(sb, u, targetField, field, path, expr), append(values, a.Amount), nil
+}
+
+// unqualifiedTarget returns column without a qualifier naming table or its
+// alias, which PostgreSQL and SQLite reject in a SET clause.
+func unqualifiedTarget(table, column string) string {
+	field, path := column, ""
+	if i := strings.Index(column, "->"); i >= 0 {
+		field, path = column[:i], column[i:]
+	}
+	i := strings.LastIndexByte(field, '.')
+	if i < 0 {
+		return column
+	}
+
+	qualifier := field[:i]
+	ref, ok := sqlutils.ParseRelationReference(table)
+	if !ok {
+		if qualifier == strings.TrimSpace(table) {
+			return field[i+1:] + path
+		}
+		return column
+	}
+	if qualifier == ref.Alias || qualifier == strings.Join(ref.Parts, ".") || qualifier == ref.Parts[len(ref.Parts)-1] {
+		return field[i+1:] + path
+	}
+	return column
+}
+
 func NewUpdateBaseBuilder(util interfaces.SQLUtils, iq *structs.UpdateQuery) *UpdateBaseBuilder {
 	return &UpdateBaseBuilder{
 		u: util,
@@ -51,6 +139,10 @@ func (m *UpdateBaseBuilder) Update(q *structs.UpdateQuery) *UpdateBaseBuilder {
 
 // UpdateBatch builds the Update query for Update.
 func (m *UpdateBaseBuilder) BuildUpdate(q *structs.UpdateQuery) (string, []interface{}, error) {
+	if err := checkReturning(m.u, q.Returning); err != nil {
+		return "", nil, err
+	}
+
 	ptr := poolBytes.Get().(*[]byte)
 	sb := *ptr
 	if len(sb) > 0 {
@@ -63,14 +155,58 @@ func (m *UpdateBaseBuilder) BuildUpdate(q *structs.UpdateQuery) (string, []inter
 		values = values[0:0]
 	}
 
-	// UPDATE
-	sb = append(sb, "UPDATE "...)
-	sb = m.u.EscapeRelation(sb, q.Table)
+	// WITH
+	withValues, err := NewWithBaseBuilder(m.u).BuildWith(&sb, q.Query.With)
+	if err != nil {
+		return "", nil, err
+	}
+	values = append(values, withValues...)
 
-	// JOIN
-	b := NewJoinBaseBuilder(m.u, q.Query.Joins)
-	joinValues := b.Join(&sb, q.Query.Joins)
-	values = append(values, joinValues...)
+	rl, err := limitRows(m.u, q.Table, q.Query, q.LimitKey, len(q.From) > 0 || hasJoins(q.Query.Joins))
+	if err != nil {
+		return "", nil, err
+	}
+
+	// UPDATE
+	sb = append(sb, "UPDATE"...)
+	sb = rl.appendTop(sb)
+	sb = append(sb, ' ')
+	joins, groups := q.Query.Joins, rl.groups
+	var from []structs.Source
+	switch m.u.Dialect() {
+	case consts.DialectPostgreSQL, consts.DialectSQLite:
+		// the joined tables are listed in FROM and joined in WHERE
+		sources, on, err := joinSources(m.u, joins)
+		if err != nil {
+			return "", nil, err
+		}
+		from = append(append(from, q.From...), sources...)
+		joins = nil
+		groups = joinWhere(on, groups)
+		sb = m.u.EscapeRelation(sb, q.Table)
+	case consts.DialectSQLServer:
+		// the target is named again in FROM, followed by the joins
+		if len(q.From) > 0 || hasJoins(joins) {
+			from = append([]structs.Source{{Name: q.Table}}, q.From...)
+			sb = m.u.EscapeReference(sb, sqlutils.RelationSelectReference(q.Table))
+		} else {
+			sb = m.u.EscapeRelation(sb, q.Table)
+		}
+	default:
+		// JOIN
+		sb = m.u.EscapeRelation(sb, q.Table)
+		b := NewJoinBaseBuilder(m.u, joins)
+		joinValues := b.Join(&sb, joins)
+		values = append(values, joinValues...)
+		joins = nil
+		if len(q.From) > 0 {
+			sb = append(sb, ", "...)
+			sb, values, err = appendSources(sb, m.u, q.From, values)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
 
 	// SET
 	sb = append(sb, " SET "...)
@@ -79,35 +215,82 @@ func (m *UpdateBaseBuilder) BuildUpdate(q *structs.UpdateQuery) (string, []inter
 		columns = append(columns, column)
 	}
 	sort.Strings(columns)
+	unqualified := m.u.Dialect() == consts.DialectPostgreSQL || m.u.Dialect() == consts.DialectSQLite
 	for i, column := range columns {
+		target := column
+		if unqualified {
+			target = unqualifiedTarget(q.Table, column)
+		}
+		if a, ok := q.Values[column].(structs.Arithmetic); ok {
+			sb, values, err = appendArithmetic(sb, m.u, target, column, a, values)
+			if err != nil {
+				return "", nil, err
+			}
+			if i < len(columns)-1 {
+				sb = append(sb, ", "...)
+			}
+			continue
+		}
+
+		var value []byte
+		value, values, err = appendValue(nil, m.u, q.Values[column], values)
+		if err != nil {
+			return "", nil, err
+		}
 		if strings.Contains(column, "->") {
 			field, path := jsonutils.ParseJsonFieldAndPath(column)
-			sb = formatJSONUpdateExpression(sb, m.u, field, path, m.u.GetPlaceholder())
+			targetField, _ := jsonutils.ParseJsonFieldAndPath(target)
+			sb = formatJSONUpdateExpression(sb, m.u, targetField, field, path, string(value))
 		} else {
-			sb = m.u.EscapeReference(sb, column)
-			sb = append(sb, " = "+m.u.GetPlaceholder()...)
+			sb = m.u.EscapeReference(sb, target)
+			sb = append(sb, " = "...)
+			sb = append(sb, value...)
 		}
 		if i < len(columns)-1 {
 			sb = append(sb, ", "...)
 		}
-		values = append(values, q.Values[column])
+	}
+
+	// OUTPUT
+	sb = appendOutput(sb, m.u, "INSERTED", q.Returning)
+
+	// FROM
+	if len(from) > 0 {
+		sb = append(sb, " FROM "...)
+		sb, values, err = appendSources(sb, m.u, from, values)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	// JOIN
+	if joins != nil {
+		b := NewJoinBaseBuilder(m.u, joins)
+		values = append(values, b.Join(&sb, joins)...)
 	}
 
 	// WHERE
-	if len(q.Query.ConditionGroups) > 0 {
-		wb := NewWhereBaseBuilder(m.u, q.Query.ConditionGroups)
-		whereValues, err := wb.Where(&sb, q.Query.ConditionGroups)
+	if len(groups) > 0 {
+		wb := NewWhereBaseBuilder(m.u, groups)
+		whereValues, err := wb.Where(&sb, groups)
 		if err != nil {
 			return "", nil, err
 		}
 		values = append(values, whereValues...)
 	}
 
-	if len(*q.Query.Order) > 0 {
-		ob := NewOrderByBaseBuilder(m.u, q.Query.Order)
-		ob.OrderBy(&sb, q.Query.Order)
+	// ORDER BY
+	if rl.order != nil && len(*rl.order) > 0 {
+		ob := NewOrderByBaseBuilder(m.u, rl.order)
+		ob.OrderBy(&sb, rl.order)
 	}
 
+	// LIMIT
+	sb = rl.appendLimit(sb)
+
+	// RETURNING
+	sb = appendReturning(sb, m.u, q.Returning)
+
 	query := string(sb)
 
 	retVals := append([]interface{}(nil), values...)
@@ -30,7 +30,7 @@ func (s *SQLUtils) EscapeAliasedValue(sb []byte, value string) []byte {
 }
 
 func (s *SQLUtils) GetQueryBuilderStrategy() interfaces.QueryBuilderStrategy {
-	return newBaseQueryBuilderWithUtil(s)
+	return NewBaseQueryBuilderWithUtil(s)
 }
 
 func (s *SQLUtils) Dialect() string {
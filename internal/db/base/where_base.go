@@ -1,6 +1,7 @@
 package base
 
 import (
+	"bytes"
 	"errors"
 
 	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
@@ -59,6 +60,10 @@ func (wb *WhereBaseBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]interf
 				cap += 1
 				continue
 			}
+			if c.Keyset != nil {
+				cap += len(c.Keyset.Values) * 2
+				continue
+			}
 			if c.Value != nil {
 				cap += len(c.Value)
 				continue
@@ -108,6 +113,14 @@ func (wb *WhereBaseBuilder) Where(sb *[]byte, wg []structs.WhereGroup) ([]interf
 				values = append(values, v...)
 			case c.Function != "":
 				values = append(values, wb.ProcessFunction(sb, c)...)
+			case c.Keyset != nil:
+				values = append(values, wb.ProcessKeyset(sb, c)...)
+			case c.Nested != nil:
+				nestedValues, err := wb.ProcessNested(sb, c, wb.Where)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, nestedValues...)
 			default:
 				rawValues, err := wb.ProcessRawCondition(sb, c)
 				if err != nil {
@@ -178,6 +191,22 @@ func (wb *WhereBaseBuilder) GetConditionOperator(c structs.Where) string {
 	return ""
 }
 
+// ProcessNested renders the groups of c.Nested in parentheses with where, the
+// Where method of the dialect.
+func (wb *WhereBaseBuilder) ProcessNested(sb *[]byte, c structs.Where, where func(sb *[]byte, wg []structs.WhereGroup) ([]interface{}, error)) ([]interface{}, error) {
+	nested := make([]byte, 0, 64)
+	values, err := where(&nested, c.Nested)
+	if err != nil {
+		return nil, err
+	}
+
+	*sb = append(*sb, '(')
+	*sb = append(*sb, bytes.TrimPrefix(nested, []byte(" WHERE "))...)
+	*sb = append(*sb, ')')
+
+	return values, nil
+}
+
 func (wb *WhereBaseBuilder) ProcessSubQuery(sb *[]byte, c structs.Where) ([]interface{}, error) {
 	*sb = wb.u.EscapeReference(*sb, c.Column)
 	*sb = append(*sb, " "...)
@@ -253,8 +282,30 @@ func (wb *WhereBaseBuilder) ProcessRawCondition(sb *[]byte, c structs.Where) ([]
 			*sb = append(*sb, " "...)
 			*sb = wb.u.EscapeReference(*sb, c.ValueColumn)
 		} else if c.Value != nil {
-			if c.Condition == consts.Condition_IN || c.Condition == consts.Condition_NOT_IN || len(c.Value) > 1 {
+			list := c.Condition == consts.Condition_IN || c.Condition == consts.Condition_NOT_IN || len(c.Value) > 1
+			if list {
 				*sb = append(*sb, " ("...)
+			} else {
+				*sb = append(*sb, " "...)
+			}
+			if hasExpression(c.Value) {
+				values := make([]interface{}, 0, len(c.Value))
+				for k, v := range c.Value {
+					if k > 0 {
+						*sb = append(*sb, ", "...)
+					}
+					var err error
+					*sb, values, err = appendValue(*sb, wb.u, v, values)
+					if err != nil {
+						return nil, err
+					}
+				}
+				if list {
+					*sb = append(*sb, ")"...)
+				}
+				return values, nil
+			}
+			if list {
 				for k := 0; k < len(c.Value); k++ {
 					if k > 0 {
 						*sb = append(*sb, ", "...)
@@ -263,7 +314,6 @@ func (wb *WhereBaseBuilder) ProcessRawCondition(sb *[]byte, c structs.Where) ([]
 				}
 				*sb = append(*sb, ")"...)
 			} else {
-				*sb = append(*sb, " "...)
 				*sb = append(*sb, wb.u.GetPlaceholder()...)
 			}
 		}
@@ -311,3 +361,77 @@ func (wb *WhereBaseBuilder) ProcessFunction(sb *[]byte, c structs.Where) []inter
 
 	return values
 }
+
+// ProcessKeyset renders a keyset condition. Columns sorted in one direction
+// use a row value comparison, mixed directions and SQL Server the expanded
+// form (a > ? OR (a = ? AND b > ?)).
+func (wb *WhereBaseBuilder) ProcessKeyset(sb *[]byte, c structs.Where) []interface{} {
+	k := c.Keyset
+	operator := func(i int) string {
+		if k.Desc[i] {
+			return " < "
+		}
+		return " > "
+	}
+
+	uniform := true
+	for i := range k.Desc {
+		if k.Desc[i] != k.Desc[0] {
+			uniform = false
+			break
+		}
+	}
+
+	if len(k.Columns) == 1 {
+		*sb = wb.u.EscapeReference(*sb, k.Columns[0])
+		*sb = append(*sb, operator(0)...)
+		*sb = append(*sb, wb.u.GetPlaceholder()...)
+		return []interface{}{k.Values[0]}
+	}
+
+	if uniform && wb.u.Dialect() != consts.DialectSQLServer {
+		*sb = append(*sb, '(')
+		for i, column := range k.Columns {
+			if i > 0 {
+				*sb = append(*sb, ", "...)
+			}
+			*sb = wb.u.EscapeReference(*sb, column)
+		}
+		*sb = append(*sb, ')')
+		*sb = append(*sb, operator(0)...)
+		*sb = append(*sb, '(')
+		for i := range k.Values {
+			if i > 0 {
+				*sb = append(*sb, ", "...)
+			}
+			*sb = append(*sb, wb.u.GetPlaceholder()...)
+		}
+		*sb = append(*sb, ')')
+		return append([]interface{}(nil), k.Values...)
+	}
+
+	values := make([]interface{}, 0, len(k.Columns)*(len(k.Columns)+1)/2)
+	*sb = append(*sb, '(')
+	for i := range k.Columns {
+		if i > 0 {
+			*sb = append(*sb, " OR ("...)
+		}
+		for j := 0; j < i; j++ {
+			*sb = wb.u.EscapeReference(*sb, k.Columns[j])
+			*sb = append(*sb, " = "...)
+			*sb = append(*sb, wb.u.GetPlaceholder()...)
+			*sb = append(*sb, " AND "...)
+			values = append(values, k.Values[j])
+		}
+		*sb = wb.u.EscapeReference(*sb, k.Columns[i])
+		*sb = append(*sb, operator(i)...)
+		*sb = append(*sb, wb.u.GetPlaceholder()...)
+		values = append(values, k.Values[i])
+		if i > 0 {
+			*sb = append(*sb, ')')
+		}
+	}
+	*sb = append(*sb, ')')
+
+	return values
+}
@@ -0,0 +1,259 @@
+package base
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type WindowBaseBuilder struct {
+	u interfaces.SQLUtils
+}
+
+func NewWindowBaseBuilder(u interfaces.SQLUtils) *WindowBaseBuilder {
+	return &WindowBaseBuilder{
+		u: u,
+	}
+}
+
+// Window renders the WINDOW clause with the named window definitions.
+func (wb WindowBaseBuilder) Window(sb *[]byte, windows []structs.NamedWindow) error {
+	if len(windows) == 0 {
+		return nil
+	}
+
+	*sb = append(*sb, " WINDOW "...)
+	for i, w := range windows {
+		if i > 0 {
+			*sb = append(*sb, ", "...)
+		}
+		*sb = wb.u.EscapeReference(*sb, w.Name)
+		*sb = append(*sb, " AS ("...)
+		spec, err := appendWindowSpec(*sb, wb.u, w.Spec)
+		if err != nil {
+			return err
+		}
+		*sb = append(spec, ")"...)
+	}
+
+	return nil
+}
+
+// appendWindowFunction renders fn(args) OVER (...). Arguments that are not
+// literals are escaped as column references; any other expression, such as
+// DISTINCT x or a*2, is rejected and must be written with a raw call.
+func appendWindowFunction(sb []byte, u interfaces.SQLUtils, w *structs.WindowFunction) ([]byte, error) {
+	if w.Raw != "" {
+		sb = append(sb, w.Raw...)
+	} else {
+		if !isWindowFunctionName(w.Function) {
+			return nil, fmt.Errorf("invalid window function: %q", w.Function)
+		}
+
+		sb = append(sb, w.Function...)
+		sb = append(sb, '(')
+		for i, arg := range w.Args {
+			if i > 0 {
+				sb = append(sb, ", "...)
+			}
+			switch {
+			case isWindowLiteral(arg):
+				sb = append(sb, arg...)
+			case isWindowReference(arg):
+				sb = u.EscapeReference(sb, arg)
+			default:
+				return nil, fmt.Errorf("invalid window function argument: %q", arg)
+			}
+		}
+		sb = append(sb, ')')
+	}
+	sb = append(sb, " OVER "...)
+
+	// a bare reference to a named window is written without parentheses
+	if w.Spec.Name != "" && len(w.Spec.PartitionBy) == 0 && len(w.Spec.Order) == 0 && w.Spec.Frame == nil {
+		return u.EscapeReference(sb, w.Spec.Name), nil
+	}
+
+	sb = append(sb, '(')
+	sb, err := appendWindowSpec(sb, u, w.Spec)
+	if err != nil {
+		return nil, err
+	}
+	return append(sb, ')'), nil
+}
+
+func appendWindowSpec(sb []byte, u interfaces.SQLUtils, spec structs.WindowSpec) ([]byte, error) {
+	sep := false
+	if spec.Name != "" {
+		sb = u.EscapeReference(sb, spec.Name)
+		sep = true
+	}
+
+	if len(spec.PartitionBy) > 0 {
+		if sep {
+			sb = append(sb, ' ')
+		}
+		sb = append(sb, "PARTITION BY "...)
+		for i, column := range spec.PartitionBy {
+			if i > 0 {
+				sb = append(sb, ", "...)
+			}
+			sb = u.EscapeReference(sb, column)
+		}
+		sep = true
+	}
+
+	if len(spec.Order) > 0 {
+		if sep {
+			sb = append(sb, ' ')
+		}
+		sb = append(sb, "ORDER BY "...)
+		for i, order := range spec.Order {
+			if i > 0 {
+				sb = append(sb, ", "...)
+			}
+			if order.Raw != "" {
+				sb = append(sb, order.Raw...)
+				continue
+			}
+			sb = u.EscapeReference(sb, order.Column)
+			if order.IsAsc {
+				sb = append(sb, " ASC"...)
+			} else {
+				sb = append(sb, " DESC"...)
+			}
+		}
+		sep = true
+	}
+
+	if spec.Frame != nil {
+		start, ok := normalizeFrameBound(spec.Frame.Start)
+		if !ok {
+			return nil, fmt.Errorf("invalid window frame bound: %q", spec.Frame.Start)
+		}
+		end, ok := normalizeFrameBound(spec.Frame.End)
+		if !ok {
+			return nil, fmt.Errorf("invalid window frame bound: %q", spec.Frame.End)
+		}
+
+		if sep {
+			sb = append(sb, ' ')
+		}
+		sb = append(sb, spec.Frame.Unit...)
+		sb = append(sb, " BETWEEN "...)
+		sb = append(sb, start...)
+		sb = append(sb, " AND "...)
+		sb = append(sb, end...)
+	}
+
+	return sb, nil
+}
+
+// normalizeFrameBound accepts UNBOUNDED PRECEDING, UNBOUNDED FOLLOWING,
+// CURRENT ROW and "<n> PRECEDING" / "<n> FOLLOWING" in any letter case.
+func normalizeFrameBound(bound string) (string, bool) {
+	fields := strings.Fields(strings.ToUpper(bound))
+	if len(fields) != 2 {
+		return "", false
+	}
+
+	switch {
+	case fields[0] == "CURRENT" && fields[1] == "ROW":
+	case fields[1] == "PRECEDING" || fields[1] == "FOLLOWING":
+		if fields[0] != "UNBOUNDED" && !isDigits(fields[0]) {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	return fields[0] + " " + fields[1], true
+}
+
+func isWindowFunctionName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		ch := name[i]
+		if ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (i > 0 && ch >= '0' && ch <= '9') {
+			continue
+		}
+		return false
+	}
+	return true
+}
+
+// isWindowReference reports whether a function argument is a column,
+// optionally qualified as in "table.column".
+func isWindowReference(arg string) bool {
+	for _, part := range strings.Split(arg, ".") {
+		if !isWindowFunctionName(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// isWindowLiteral reports whether a function argument is written as is:
+// numbers, quoted strings, NULL, booleans and the * wildcard.
+func isWindowLiteral(arg string) bool {
+	if arg == "*" || isQuotedLiteral(arg) {
+		return true
+	}
+
+	switch strings.ToUpper(arg) {
+	case "NULL", "TRUE", "FALSE":
+		return true
+	}
+
+	number := strings.TrimPrefix(arg, "-")
+	dot, digits := false, false
+	for i := 0; i < len(number); i++ {
+		if number[i] == '.' && !dot {
+			dot = true
+			continue
+		}
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+		digits = true
+	}
+	return digits
+}
+
+// isQuotedLiteral reports whether s is a single quoted string whose inner
+// quotes are all doubled. Backslashes are rejected because MySQL treats them
+// as escape characters.
+func isQuotedLiteral(s string) bool {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return false
+	}
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' {
+			return false
+		}
+		if s[i] != '\'' {
+			continue
+		}
+		if i+1 >= len(s)-1 || s[i+1] != '\'' {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,129 @@
+package base
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrDuplicateCTE is returned for two different common table expressions of
+// the same name in the queries of a UNION.
+var ErrDuplicateCTE = errors.New("duplicate common table expression")
+
+type WithBaseBuilder struct {
+	u interfaces.SQLUtils
+}
+
+func NewWithBaseBuilder(u interfaces.SQLUtils) *WithBaseBuilder {
+	return &WithBaseBuilder{
+		u: u,
+	}
+}
+
+// With renders the WITH clause of a SELECT statement. When the statement is a
+// UNION, the common table expressions of all its queries are merged and
+// rendered once, in front of the first SELECT. An expression attached to
+// several queries is rendered once.
+func (wb WithBaseBuilder) With(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error) {
+	if unions == nil || len(*unions) == 0 {
+		return wb.BuildWith(sb, q.With)
+	}
+	if number > 0 {
+		return nil, nil
+	}
+
+	var ctes []structs.CTE
+	seen := make(map[string]structs.CTE)
+	for _, union := range *unions {
+		for _, cte := range union.Query.With {
+			if prev, ok := seen[cte.Name]; ok {
+				if prev.Query != cte.Query || prev.RecursiveQuery != cte.RecursiveQuery {
+					return nil, fmt.Errorf("%w: %q", ErrDuplicateCTE, cte.Name)
+				}
+				continue
+			}
+			seen[cte.Name] = cte
+			ctes = append(ctes, cte)
+		}
+	}
+
+	return wb.BuildWith(sb, ctes)
+}
+
+// BuildWith renders the given common table expressions followed by a space.
+func (wb WithBaseBuilder) BuildWith(sb *[]byte, ctes []structs.CTE) ([]interface{}, error) {
+	if len(ctes) == 0 {
+		return nil, nil
+	}
+
+	*sb = append(*sb, "WITH "...)
+	if wb.hasRecursive(ctes) && wb.u.Dialect() != consts.DialectSQLServer {
+		*sb = append(*sb, "RECURSIVE "...)
+	}
+
+	values := make([]interface{}, 0)
+	b := wb.u.GetQueryBuilderStrategy()
+	for i, cte := range ctes {
+		if i > 0 {
+			*sb = append(*sb, ", "...)
+		}
+		*sb = wb.u.EscapeReference(*sb, cte.Name)
+		if len(cte.Columns) > 0 {
+			*sb = append(*sb, " ("...)
+			for j, column := range cte.Columns {
+				if j > 0 {
+					*sb = append(*sb, ", "...)
+				}
+				*sb = wb.u.EscapeReference(*sb, column)
+			}
+			*sb = append(*sb, ")"...)
+		}
+		*sb = append(*sb, " AS "...)
+		if cte.Materialized && wb.supportsMaterialized() {
+			*sb = append(*sb, "MATERIALIZED "...)
+		}
+
+		*sb = append(*sb, "("...)
+		v, err := b.Build(sb, cte.Query, 0, nil)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v...)
+
+		if cte.RecursiveQuery != nil {
+			*sb = append(*sb, " UNION ALL "...)
+			v, err := b.Build(sb, cte.RecursiveQuery, 0, nil)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v...)
+		}
+		*sb = append(*sb, ")"...)
+	}
+	*sb = append(*sb, " "...)
+
+	return values, nil
+}
+
+func (wb WithBaseBuilder) hasRecursive(ctes []structs.CTE) bool {
+	for _, cte := range ctes {
+		if cte.Recursive {
+			return true
+		}
+	}
+	return false
+}
+
+// supportsMaterialized reports whether the dialect accepts the MATERIALIZED
+// hint. Other dialects silently ignore it.
+func (wb WithBaseBuilder) supportsMaterialized() bool {
+	switch wb.u.Dialect() {
+	case consts.DialectPostgreSQL, consts.DialectSQLite:
+		return true
+	default:
+		return false
+	}
+}
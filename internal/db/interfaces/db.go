@@ -1,11 +1,24 @@
 package interfaces
 
 import (
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
 )
 
 type QueryBuilderStrategy interface {
+	// Deprecated: placeholders are numbered per statement by ForBuild, so
+	// there is no counter to reset.
 	ResetPlaceholderCounter()
+	// ForBuild returns the strategy one statement is rendered with. Dialects
+	// that number their placeholders return a pooled strategy with its own
+	// counter, so a strategy can be shared between goroutines.
+	ForBuild() QueryBuilderStrategy
+	Dialect() string
+	Hooks() *hook.Chain
+	Scopes() *scope.Registry
+	Timestamps() *timestamp.Registry
 
 	Build(sb *[]byte, q *structs.Query, number int, unions *[]structs.Union) ([]interface{}, error)
 
@@ -18,4 +31,12 @@ type QueryBuilderStrategy interface {
 	BuildUpdate(q *structs.UpdateQuery) (string, []interface{}, error)
 
 	BuildDelete(q *structs.DeleteQuery) (string, []interface{}, error)
+
+	BuildSchema(q *structs.SchemaQuery) ([]string, error)
+}
+
+// Releaser is implemented by the strategies ForBuild takes from a pool. The
+// builders call Release once the statement is rendered.
+type Releaser interface {
+	Release()
 }
@@ -0,0 +1,157 @@
+package query
+
+import (
+	"slices"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// Clone returns a copy of the builder that can be changed and built
+// independently of b, also from another goroutine. Subqueries and the values
+// of conditions are shared; the builders never modify them once added.
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	c := NewSelectBuilder(b.dbBuilder)
+	c.query = cloneQuery(b.query)
+	c.selectQuery = cloneSelectQuery(b.selectQuery)
+	c.WhereBuilder.query = cloneQuery(b.WhereBuilder.query)
+	c.JoinBuilder.Table = cloneTable(b.JoinBuilder.Table)
+	c.JoinBuilder.Joins = cloneJoins(b.JoinBuilder.Joins)
+	c.OrderByBuilder.Order = clonePtrSlice(b.OrderByBuilder.Order)
+	c.WithBuilder.CTEs = clonePtrSlice(b.WithBuilder.CTEs)
+	if b.cursor != nil {
+		cursor := *b.cursor
+		c.cursor = &cursor
+	}
+	c.scopes = b.scopes.clone()
+	c.err = b.err
+	return c
+}
+
+// Clone returns a copy of the builder that can be changed and built
+// independently of ib.
+func (ib *InsertBuilder) Clone() *InsertBuilder {
+	c := NewInsertBuilder(ib.dbBuilder)
+	q := *ib.query
+	c.query = &q
+	c.WithBuilder.CTEs = clonePtrSlice(ib.WithBuilder.CTEs)
+	c.err = ib.err
+	return c
+}
+
+// Clone returns a copy of the builder that can be changed and built
+// independently of b.
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := NewUpdateBuilder(b.dbBuilder)
+	q := *b.query
+	q.Query = cloneQuery(b.query.Query)
+	q.From = slices.Clip(q.From)
+	c.query = &q
+	c.WhereBuilder.query = cloneQuery(b.WhereBuilder.query)
+	c.JoinBuilder.Table = cloneTable(b.JoinBuilder.Table)
+	c.JoinBuilder.Joins = cloneJoins(b.JoinBuilder.Joins)
+	c.OrderByBuilder.Order = clonePtrSlice(b.OrderByBuilder.Order)
+	c.WithBuilder.CTEs = clonePtrSlice(b.WithBuilder.CTEs)
+	c.scopes = b.scopes.clone()
+	c.unkeyed = b.unkeyed
+	c.err = b.err
+	return c
+}
+
+// Clone returns a copy of the builder that can be changed and built
+// independently of b.
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := NewDeleteBuilder(b.dbBuilder)
+	q := *b.query
+	q.Query = cloneQuery(b.query.Query)
+	q.Using = slices.Clip(q.Using)
+	c.query = &q
+	c.WhereBuilder.query = cloneQuery(b.WhereBuilder.query)
+	c.JoinBuilder.Table = cloneTable(b.JoinBuilder.Table)
+	c.JoinBuilder.Joins = cloneJoins(b.JoinBuilder.Joins)
+	c.OrderByBuilder.Order = clonePtrSlice(b.OrderByBuilder.Order)
+	c.WithBuilder.CTEs = clonePtrSlice(b.WithBuilder.CTEs)
+	c.scopes = b.scopes.clone()
+	c.mode = b.mode
+	c.err = b.err
+	return c
+}
+
+// cloneQuery copies q and every slice the builders append to or modify in
+// place.
+func cloneQuery(q *structs.Query) *structs.Query {
+	if q == nil {
+		return nil
+	}
+
+	c := *q
+	c.Columns = clonePtrSlice(q.Columns)
+	c.Joins = cloneJoins(q.Joins)
+	c.ConditionGroups = slices.Clone(q.ConditionGroups)
+	c.Conditions = clonePtrSlice(q.Conditions)
+	c.Order = clonePtrSlice(q.Order)
+	c.Group = cloneGroupBy(q.Group)
+	c.Lock = cloneLock(q.Lock)
+	c.With = slices.Clone(q.With)
+	c.Windows = slices.Clone(q.Windows)
+	return &c
+}
+
+func cloneSelectQuery(q *structs.SelectQuery) *structs.SelectQuery {
+	c := *q
+	c.Columns = clonePtrSlice(q.Columns)
+	c.Union = clonePtrSlice(q.Union)
+	c.Group = cloneGroupBy(q.Group)
+	c.Lock = cloneLock(q.Lock)
+	c.Windows = slices.Clone(q.Windows)
+	return &c
+}
+
+func cloneJoins(j *structs.Joins) *structs.Joins {
+	if j == nil {
+		return nil
+	}
+
+	c := *j
+	c.Joins = clonePtrSlice(j.Joins)
+	c.JoinClauses = clonePtrSlice(j.JoinClauses)
+	c.LateralJoins = clonePtrSlice(j.LateralJoins)
+	return &c
+}
+
+func cloneGroupBy(g *structs.GroupBy) *structs.GroupBy {
+	if g == nil {
+		return nil
+	}
+
+	c := *g
+	c.Columns = slices.Clone(g.Columns)
+	c.Having = clonePtrSlice(g.Having)
+	return &c
+}
+
+func cloneLock(l *structs.Lock) *structs.Lock {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	return &c
+}
+
+func cloneTable(t *structs.Table) *structs.Table {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	return &c
+}
+
+func clonePtrSlice[T any](s *[]T) *[]T {
+	if s == nil {
+		return nil
+	}
+
+	c := slices.Clone(*s)
+	return &c
+}
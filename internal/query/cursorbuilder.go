@@ -0,0 +1,204 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// DefaultCursorTiebreaker is the unique column a cursor order must contain
+// unless another one is set with CursorTiebreaker.
+const DefaultCursorTiebreaker = "id"
+
+var (
+	ErrInvalidPerPage      = errors.New("perPage must be greater than zero")
+	ErrCursorNoOrder       = errors.New("cursor pagination requires an ORDER BY")
+	ErrCursorRawOrder      = errors.New("cursor pagination cannot use a raw ORDER BY")
+	ErrCursorNoTiebreaker  = errors.New("cursor pagination requires a unique tiebreaker column in the ORDER BY")
+	ErrCursorInvalid       = errors.New("invalid cursor")
+	ErrCursorOrderMismatch = errors.New("cursor does not match the ORDER BY")
+)
+
+type cursorState struct {
+	perPage    int
+	columns    []string
+	values     []interface{}
+	tiebreaker string
+}
+
+// cursorPayload is the JSON inside the base64 cursor.
+type cursorPayload struct {
+	Columns []string      `json:"c"`
+	Values  []interface{} `json:"v"`
+}
+
+// CursorPaginate limits the query to perPage+1 rows following the row encoded
+// in cursor. An empty cursor starts at the first page. The ORDER BY is read
+// when the query is built and must contain the tiebreaker column.
+func (b *SelectBuilder) CursorPaginate(perPage int, cursor string) *SelectBuilder {
+	if perPage < 1 {
+		b.err = ErrInvalidPerPage
+		return b
+	}
+
+	state := &cursorState{perPage: perPage, tiebreaker: DefaultCursorTiebreaker}
+	if b.cursor != nil {
+		state.tiebreaker = b.cursor.tiebreaker
+	}
+
+	if cursor != "" {
+		payload, err := decodeCursor(cursor)
+		if err != nil {
+			b.err = err
+			return b
+		}
+		state.columns = payload.Columns
+		state.values = payload.Values
+	}
+
+	b.cursor = state
+	b.selectQuery.Limit.Limit = int64(perPage) + 1
+	return b
+}
+
+// CursorTiebreaker sets the unique column the cursor order must contain.
+func (b *SelectBuilder) CursorTiebreaker(column string) *SelectBuilder {
+	if b.cursor == nil {
+		b.cursor = &cursorState{}
+	}
+	b.cursor.tiebreaker = column
+	return b
+}
+
+// CursorPerPage returns the page size set with CursorPaginate, or 0.
+func (b *SelectBuilder) CursorPerPage() int {
+	if b.cursor == nil {
+		return 0
+	}
+	return b.cursor.perPage
+}
+
+// EncodeCursor returns the cursor pointing after row, read from the ORDER BY
+// columns. A qualified column such as "users.id" is read as "id".
+func (b *SelectBuilder) EncodeCursor(row map[string]interface{}) (string, error) {
+	order := *b.OrderByBuilder.Order
+	payload := cursorPayload{
+		Columns: make([]string, 0, len(order)),
+		Values:  make([]interface{}, 0, len(order)),
+	}
+	for _, o := range order {
+		if o.Raw != "" {
+			return "", ErrCursorRawOrder
+		}
+		key := o.Column
+		if _, ok := row[key]; !ok {
+			if i := strings.LastIndexByte(key, '.'); i >= 0 {
+				key = key[i+1:]
+			}
+		}
+		v, ok := row[key]
+		if !ok {
+			return "", fmt.Errorf("cursor column %q is missing from the row", o.Column)
+		}
+		payload.Columns = append(payload.Columns, o.Column)
+		payload.Values = append(payload.Values, v)
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// cursorCondition validates the order and returns the keyset condition, or
+// nil on the first page.
+func (b *SelectBuilder) cursorCondition() (*structs.WhereGroup, error) {
+	if b.cursor == nil || b.cursor.perPage == 0 {
+		return nil, nil
+	}
+
+	order := *b.OrderByBuilder.Order
+	if len(order) == 0 {
+		return nil, ErrCursorNoOrder
+	}
+
+	keyset := &structs.Keyset{
+		Columns: make([]string, 0, len(order)),
+		Desc:    make([]bool, 0, len(order)),
+	}
+	hasTiebreaker := false
+	for _, o := range order {
+		if o.Raw != "" {
+			return nil, ErrCursorRawOrder
+		}
+		if o.Column == b.cursor.tiebreaker || strings.HasSuffix(o.Column, "."+b.cursor.tiebreaker) {
+			hasTiebreaker = true
+		}
+		keyset.Columns = append(keyset.Columns, o.Column)
+		keyset.Desc = append(keyset.Desc, !o.IsAsc)
+	}
+	if !hasTiebreaker {
+		return nil, ErrCursorNoTiebreaker
+	}
+
+	if b.cursor.values == nil {
+		return nil, nil
+	}
+
+	if len(b.cursor.columns) != len(keyset.Columns) {
+		return nil, ErrCursorOrderMismatch
+	}
+	for i := range keyset.Columns {
+		if b.cursor.columns[i] != keyset.Columns[i] {
+			return nil, ErrCursorOrderMismatch
+		}
+	}
+	keyset.Values = b.cursor.values
+
+	return &structs.WhereGroup{
+		Conditions: []structs.Where{{
+			Keyset:   keyset,
+			Operator: consts.LogicalOperator_AND,
+		}},
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	}, nil
+}
+
+func decodeCursor(cursor string) (*cursorPayload, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCursorInvalid, err)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(string(data)))
+	dec.UseNumber()
+	var payload cursorPayload
+	if err := dec.Decode(&payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCursorInvalid, err)
+	}
+	if len(payload.Columns) == 0 || len(payload.Columns) != len(payload.Values) {
+		return nil, ErrCursorInvalid
+	}
+
+	// numbers are kept exact; integers go back to int64
+	for i, v := range payload.Values {
+		n, ok := v.(json.Number)
+		if !ok {
+			continue
+		}
+		if iv, err := n.Int64(); err == nil {
+			payload.Values[i] = iv
+		} else if fv, err := n.Float64(); err == nil {
+			payload.Values[i] = fv
+		}
+	}
+
+	return &payload, nil
+}
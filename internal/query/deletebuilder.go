@@ -1,7 +1,7 @@
 package query
 
 import (
-	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 )
@@ -12,6 +12,10 @@ type DeleteBuilder struct {
 	WhereBuilder[DeleteBuilder]
 	JoinBuilder[DeleteBuilder]
 	OrderByBuilder[DeleteBuilder]
+	WithBuilder[DeleteBuilder]
+	scopes scopes
+	mode   int
+	err    error
 }
 
 func NewDeleteBuilder(strategy interfaces.QueryBuilderStrategy) *DeleteBuilder {
@@ -34,6 +38,10 @@ func NewDeleteBuilder(strategy interfaces.QueryBuilderStrategy) *DeleteBuilder {
 	orderByBuilder.SetParent(db)
 	db.OrderByBuilder = *orderByBuilder
 
+	withBuilder := NewWithBuilder[DeleteBuilder](strategy)
+	withBuilder.SetParent(db)
+	db.WithBuilder = *withBuilder
+
 	return db
 }
 
@@ -43,31 +51,95 @@ func (b *DeleteBuilder) Table(table string) *DeleteBuilder {
 	return b
 }
 
+// Scope applies the named scopes registered for the table of the query. It
+// must be called after Table.
+func (b *DeleteBuilder) Scope(names ...string) *DeleteBuilder {
+	if err := b.scopes.add(b.dbBuilder, b.query.Table, names); err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (b *DeleteBuilder) WithoutGlobalScope(names ...string) *DeleteBuilder {
+	b.scopes.without = append(b.scopes.without, names...)
+	return b
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (b *DeleteBuilder) WithoutGlobalScopes() *DeleteBuilder {
+	b.scopes.withoutAll = true
+	return b
+}
+
 // Delete
 func (b *DeleteBuilder) Delete() *DeleteBuilder {
 	return b
 }
 
+// Using adds a table the delete reads from besides its target.
+func (b *DeleteBuilder) Using(table string) *DeleteBuilder {
+	b.query.Using = append(b.query.Using, structs.Source{Name: table})
+	return b
+}
+
+// UsingSub adds a subquery, named alias, the delete reads from besides its
+// target.
+func (b *DeleteBuilder) UsingSub(q *SelectBuilder, alias string) *DeleteBuilder {
+	b.query.Using = append(b.query.Using, structs.Source{Name: alias, Query: q.GetQuery()})
+	return b
+}
+
+// Limit limits the number of rows the statement deletes.
+func (b *DeleteBuilder) Limit(limit int64) *DeleteBuilder {
+	b.query.Query.Limit.Limit = limit
+	return b
+}
+
+// LimitKey sets the column, usually the primary key, matching the limited
+// rows on dialects that emulate Limit with a subquery.
+func (b *DeleteBuilder) LimitKey(column string) *DeleteBuilder {
+	b.query.LimitKey = column
+	return b
+}
+
+// Returning sets the columns returned for the deleted rows.
+func (b *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	b.query.Returning = columns
+	return b
+}
+
 func (d *DeleteBuilder) Build() (string, []interface{}, error) {
-	d.dbBuilder.ResetPlaceholderCounter()
-
-	// If there are conditions, add them to the query
-	if len(*d.WhereBuilder.query.Conditions) > 0 {
-		d.WhereBuilder.query.ConditionGroups = append(d.WhereBuilder.query.ConditionGroups, structs.WhereGroup{
-			Conditions:   *d.WhereBuilder.query.Conditions,
-			Operator:     consts.LogicalOperator_AND,
-			IsDummyGroup: true,
-		})
-		d.WhereBuilder.query.Conditions = &[]structs.Where{}
+	if d.err != nil {
+		return "", nil, d.err
+	}
+
+	// the builder is left unchanged so it can be built concurrently
+	q, sq := *d.query, *d.query.Query
+	sq.Conditions = &[]structs.Where{}
+	sq.ConditionGroups = d.scopes.apply(d.dbBuilder, d.query.Table, d.WhereBuilder.conditionGroups())
+	sq.Joins = d.JoinBuilder.Joins
+	sq.Order = d.OrderByBuilder.Order
+	sq.With = *d.WithBuilder.CTEs
+	q.Query = &sq
+
+	if d.mode != deleteRows {
+		return d.buildSoftDelete(&q)
 	}
 
-	d.query.Query.Conditions = d.WhereBuilder.query.Conditions
-	d.query.Query.ConditionGroups = d.WhereBuilder.query.ConditionGroups
-	d.query.Query.Joins = d.JoinBuilder.Joins
-	d.query.Query.Order = d.OrderByBuilder.Order
+	hooks := d.dbBuilder.Hooks()
+	if hooks.Len() == 0 {
+		return buildDelete(d.dbBuilder, &q)
+	}
+
+	e := &hook.Event{Kind: hook.KindDelete, Delete: &q}
+	return runHooks(hooks, e, func() (string, []interface{}, error) {
+		return buildDelete(d.dbBuilder, e.Delete)
+	})
+}
 
-	query, values, err := d.dbBuilder.BuildDelete(d.query)
-	return query, values, err
+func (b *DeleteBuilder) GetStrategy() interfaces.QueryBuilderStrategy {
+	return b.dbBuilder
 }
 
 /*
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/hook"
+)
+
+// runHooks calls the build hooks of the strategy around render.
+func runHooks(hooks *hook.Chain, e *hook.Event, render func() (string, []interface{}, error)) (string, []interface{}, error) {
+	if err := hooks.BeforeBuild(e); err != nil {
+		return "", nil, err
+	}
+
+	query, values, err := render()
+	if err != nil {
+		return "", nil, err
+	}
+
+	e.SQL, e.Args = query, values
+	if err := hooks.AfterBuild(e); err != nil {
+		return "", nil, err
+	}
+	return e.SQL, e.Args, nil
+}
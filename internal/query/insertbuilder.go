@@ -1,22 +1,39 @@
 package query
 
 import (
+	"errors"
+	"maps"
+
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 )
 
+// ErrCTEWithoutSelect is returned by Build for common table expressions added
+// to an insert that is not an InsertUsing, which has no query to use them.
+var ErrCTEWithoutSelect = errors.New("common table expressions need an insert using a select query")
+
 type InsertBuilder struct {
 	BaseBuilder
+	WithBuilder[InsertBuilder]
 	dbBuilder interfaces.QueryBuilderStrategy
 	query     *structs.InsertQuery
+	err       error
 }
 
 func NewInsertBuilder(dbBuilder interfaces.QueryBuilderStrategy) *InsertBuilder {
-	return &InsertBuilder{
+	ib := &InsertBuilder{
 		dbBuilder: dbBuilder,
 		query:     &structs.InsertQuery{},
 	}
+
+	withBuilder := NewWithBuilder[InsertBuilder](dbBuilder)
+	withBuilder.SetParent(ib)
+	ib.WithBuilder = *withBuilder
+
+	return ib
 }
 
 func (ib *InsertBuilder) Table(table string) *InsertBuilder {
@@ -34,6 +51,26 @@ func (ib *InsertBuilder) InsertBatch(data []map[string]interface{}) *InsertBuild
 	return ib
 }
 
+// InsertStruct inserts a row from the db tags of a struct.
+func (ib *InsertBuilder) InsertStruct(v any) *InsertBuilder {
+	values, err := structutils.InsertValues(v)
+	if err != nil {
+		ib.err = err
+		return ib
+	}
+	return ib.Insert(values)
+}
+
+// InsertStructs inserts a row for every struct in a slice.
+func (ib *InsertBuilder) InsertStructs(slice any) *InsertBuilder {
+	batch, err := structutils.InsertValuesBatch(slice)
+	if err != nil {
+		ib.err = err
+		return ib
+	}
+	return ib.InsertBatch(batch)
+}
+
 func (ib *InsertBuilder) InsertOrIgnore(data []map[string]interface{}) *InsertBuilder {
 	ib.query.ValuesBatch = data
 	ib.query.Ignore = true
@@ -46,6 +83,23 @@ func (ib *InsertBuilder) Upsert(data []map[string]interface{}, unique []string,
 	return ib
 }
 
+// UpsertSet sets columns of the conflicting rows of an upsert to values, such
+// as expressions, instead of the inserted values.
+func (ib *InsertBuilder) UpsertSet(values map[string]interface{}) *InsertBuilder {
+	u := structs.Upsert{}
+	if ib.query.Upsert != nil {
+		u = *ib.query.Upsert
+	}
+	// copied, as clones share the upsert
+	u.UpdateValues = maps.Clone(u.UpdateValues)
+	if u.UpdateValues == nil {
+		u.UpdateValues = make(map[string]interface{}, len(values))
+	}
+	maps.Copy(u.UpdateValues, values)
+	ib.query.Upsert = &u
+	return ib
+}
+
 func (ib *InsertBuilder) UpdateOrInsert(condition map[string]interface{}, values map[string]interface{}) *InsertBuilder {
 	merged := make(map[string]interface{})
 	for k, v := range condition {
@@ -86,8 +140,50 @@ func (ib *InsertBuilder) InsertUsing(columns []string, b *SelectBuilder) *Insert
 	return ib
 }
 
+// Returning sets the columns returned for the inserted rows.
+func (ib *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	ib.query.Returning = columns
+	return ib
+}
+
 func (ib *InsertBuilder) Build() (string, []interface{}, error) {
-	ib.dbBuilder.ResetPlaceholderCounter()
-	query, values, err := ib.dbBuilder.BuildInsert(ib.query)
-	return query, values, err
+	if ib.err != nil {
+		return "", nil, ib.err
+	}
+
+	return ib.build(ib.query)
+}
+
+// build renders q, with the timestamps of its table, through the hooks.
+func (ib *InsertBuilder) build(q *structs.InsertQuery) (string, []interface{}, error) {
+	// common table expressions are only meaningful for INSERT ... SELECT; they
+	// are moved in front of the CTEs of the select query.
+	if q.Query == nil && len(*ib.WithBuilder.CTEs) > 0 {
+		return "", nil, ErrCTEWithoutSelect
+	}
+
+	q = ib.withTimestamps(q)
+	if q.Query != nil && len(*ib.WithBuilder.CTEs) > 0 {
+		selectQuery := *q.Query
+		selectQuery.With = append(append([]structs.CTE{}, *ib.WithBuilder.CTEs...), q.Query.With...)
+
+		insertQuery := *q
+		insertQuery.Query = &selectQuery
+		q = &insertQuery
+	}
+
+	hooks := ib.dbBuilder.Hooks()
+	if hooks.Len() == 0 {
+		return buildInsert(ib.dbBuilder, q)
+	}
+
+	qc := *q
+	e := &hook.Event{Kind: hook.KindInsert, Insert: &qc}
+	return runHooks(hooks, e, func() (string, []interface{}, error) {
+		return buildInsert(ib.dbBuilder, e.Insert)
+	})
+}
+
+func (ib *InsertBuilder) GetStrategy() interfaces.QueryBuilderStrategy {
+	return ib.dbBuilder
 }
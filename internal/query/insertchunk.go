@@ -0,0 +1,182 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// ErrRowTooLarge is returned by BuildChunked for a row that alone exceeds the
+// limits of a statement.
+var ErrRowTooLarge = errors.New("row exceeds the statement limits")
+
+// ChunkOptions limits the statements of BuildChunked. A zero field takes the
+// default of the dialect; a negative one disables the limit.
+type ChunkOptions struct {
+	// MaxParams is the most bound values of a statement. It defaults to 65535
+	// on MySQL and PostgreSQL, 32766 on SQLite and 2100 on SQL Server.
+	MaxParams int
+	// MaxRows is the most rows of a statement. It defaults to 1000 on SQL
+	// Server, the most rows of a VALUES list, and is unlimited otherwise.
+	MaxRows int
+	// MaxBytes is the most bytes of a statement, estimated from the size of
+	// its placeholders and bound values. It defaults to 4 MiB on MySQL, below
+	// the smallest default of max_allowed_packet, and is unlimited otherwise.
+	MaxBytes int
+}
+
+// Statement is a built query with its bound values.
+type Statement struct {
+	Query  string
+	Values []interface{}
+}
+
+// the bytes counted for a placeholder and its separator
+const placeholderBytes = 8
+
+// withDefaults returns o with the zero limits set to those of dialect and the
+// disabled ones set to zero.
+func (o ChunkOptions) withDefaults(dialect string) ChunkOptions {
+	var d ChunkOptions
+	switch dialect {
+	case consts.DialectMySQL:
+		d = ChunkOptions{MaxParams: 65535, MaxBytes: 4 << 20}
+	case consts.DialectPostgreSQL:
+		d = ChunkOptions{MaxParams: 65535}
+	case consts.DialectSQLite:
+		d = ChunkOptions{MaxParams: 32766}
+	case consts.DialectSQLServer:
+		d = ChunkOptions{MaxParams: 2100, MaxRows: 1000}
+	}
+
+	limit := func(v, def int) int {
+		switch {
+		case v < 0:
+			return 0
+		case v == 0:
+			return def
+		}
+		return v
+	}
+	return ChunkOptions{
+		MaxParams: limit(o.MaxParams, d.MaxParams),
+		MaxRows:   limit(o.MaxRows, d.MaxRows),
+		MaxBytes:  limit(o.MaxBytes, d.MaxBytes),
+	}
+}
+
+// BuildChunked builds the rows of InsertBatch, InsertOrIgnore or Upsert into
+// as many statements as the limits of opts require. Every statement lists the
+// columns of all rows in the same order; a row missing a column inserts NULL.
+// Other inserts are built as a single statement, and no rows as none.
+func (ib *InsertBuilder) BuildChunked(opts ChunkOptions) ([]Statement, error) {
+	if ib.err != nil {
+		return nil, ib.err
+	}
+
+	q := ib.withTimestamps(ib.query)
+	if len(q.ValuesBatch) == 0 && len(q.Values) == 0 && q.Query == nil {
+		return []Statement{}, nil
+	}
+	if len(q.ValuesBatch) == 0 || q.Query != nil {
+		query, values, err := ib.build(q)
+		if err != nil {
+			return nil, err
+		}
+		return []Statement{{Query: query, Values: values}}, nil
+	}
+
+	opts = opts.withDefaults(ib.dbBuilder.Dialect())
+
+	columns := make(map[string]struct{})
+	for _, row := range q.ValuesBatch {
+		for column := range row {
+			columns[column] = struct{}{}
+		}
+	}
+
+	// the values of the upsert are bound in every statement
+	fixedParams := 0
+	if q.Upsert != nil {
+		for _, v := range q.Upsert.UpdateValues {
+			fixedParams += paramCount(v)
+		}
+	}
+
+	var statements []Statement
+	var chunk []map[string]interface{}
+	params, size := fixedParams, 0
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		c := *q
+		c.ValuesBatch = chunk
+		query, values, err := ib.build(&c)
+		if err != nil {
+			return err
+		}
+		statements = append(statements, Statement{Query: query, Values: values})
+		chunk, params, size = nil, fixedParams, 0
+		return nil
+	}
+
+	for i, row := range q.ValuesBatch {
+		padded := make(map[string]interface{}, len(columns))
+		rowParams, rowSize := 0, 0
+		for column := range columns {
+			v := row[column]
+			padded[column] = v
+			rowParams += paramCount(v)
+			rowSize += valueSize(v)
+		}
+
+		if (opts.MaxParams > 0 && fixedParams+rowParams > opts.MaxParams) ||
+			(opts.MaxBytes > 0 && rowSize > opts.MaxBytes) {
+			return nil, fmt.Errorf("%w: row %d", ErrRowTooLarge, i)
+		}
+		if (opts.MaxParams > 0 && params+rowParams > opts.MaxParams) ||
+			(opts.MaxRows > 0 && len(chunk) == opts.MaxRows) ||
+			(opts.MaxBytes > 0 && size+rowSize > opts.MaxBytes) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+
+		chunk = append(chunk, padded)
+		params += rowParams
+		size += rowSize
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return statements, nil
+}
+
+// paramCount returns the number of values bound for the value v.
+func paramCount(v interface{}) int {
+	if e, ok := v.(structs.Expression); ok {
+		return len(e.Values)
+	}
+	return 1
+}
+
+// valueSize estimates the bytes a value adds to a statement.
+func valueSize(v interface{}) int {
+	switch v := v.(type) {
+	case string:
+		return placeholderBytes + len(v)
+	case []byte:
+		return placeholderBytes + len(v)
+	case structs.Expression:
+		size := len(v.SQL)
+		for _, b := range v.Values {
+			size += valueSize(b)
+		}
+		return size
+	}
+	return placeholderBytes + 8
+}
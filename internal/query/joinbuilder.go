@@ -148,7 +148,7 @@ func (b *JoinBuilder[T]) joinSubCommon(joinType string, q *SelectBuilder, alias,
 	*q.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: q.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: q.scopes.apply(q.dbBuilder, q.selectQuery.Table, q.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: q.selectQuery.Table},
 		Columns:         q.selectQuery.Columns,
 		Joins:           q.JoinBuilder.Joins,
@@ -190,7 +190,7 @@ func (b *JoinBuilder[T]) joinLateralCommon(joinType string, q *SelectBuilder, al
 	*q.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: q.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: q.scopes.apply(q.dbBuilder, q.selectQuery.Table, q.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: q.selectQuery.Table},
 		Columns:         q.selectQuery.Columns,
 		Joins:           q.JoinBuilder.Joins,
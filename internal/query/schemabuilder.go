@@ -0,0 +1,353 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type SchemaBuilder struct {
+	dbBuilder interfaces.QueryBuilderStrategy
+	commands  []*schemaCommand
+}
+
+type schemaCommand struct {
+	command   string
+	blueprint *Blueprint
+	to        string
+}
+
+func NewSchemaBuilder(strategy interfaces.QueryBuilderStrategy) *SchemaBuilder {
+	return &SchemaBuilder{
+		dbBuilder: strategy,
+		commands:  make([]*schemaCommand, 0),
+	}
+}
+
+// CreateTable adds a CREATE TABLE statement defined by fn.
+func (s *SchemaBuilder) CreateTable(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	return s.addBlueprint(consts.Schema_CREATE, table, fn)
+}
+
+// CreateTableIfNotExists adds a CREATE TABLE statement that is skipped when the
+// table already exists.
+func (s *SchemaBuilder) CreateTableIfNotExists(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	return s.addBlueprint(consts.Schema_CREATE_IF_NOT_EXISTS, table, fn)
+}
+
+// AlterTable adds the ALTER TABLE statements defined by fn.
+func (s *SchemaBuilder) AlterTable(table string, fn func(t *Blueprint)) *SchemaBuilder {
+	return s.addBlueprint(consts.Schema_ALTER, table, fn)
+}
+
+func (s *SchemaBuilder) DropTable(table string) *SchemaBuilder {
+	s.commands = append(s.commands, &schemaCommand{command: consts.Schema_DROP, blueprint: NewBlueprint(table)})
+	return s
+}
+
+func (s *SchemaBuilder) DropTableIfExists(table string) *SchemaBuilder {
+	s.commands = append(s.commands, &schemaCommand{command: consts.Schema_DROP_IF_EXISTS, blueprint: NewBlueprint(table)})
+	return s
+}
+
+func (s *SchemaBuilder) RenameTable(from string, to string) *SchemaBuilder {
+	s.commands = append(s.commands, &schemaCommand{command: consts.Schema_RENAME, blueprint: NewBlueprint(from), to: to})
+	return s
+}
+
+func (s *SchemaBuilder) addBlueprint(command string, table string, fn func(t *Blueprint)) *SchemaBuilder {
+	bp := NewBlueprint(table)
+	if fn != nil {
+		fn(bp)
+	}
+	s.commands = append(s.commands, &schemaCommand{command: command, blueprint: bp})
+	return s
+}
+
+// Build returns the statements of every command in the order they were added.
+func (s *SchemaBuilder) Build() ([]string, error) {
+	statements := make([]string, 0, len(s.commands))
+	for _, cmd := range s.commands {
+		stmts, err := s.dbBuilder.BuildSchema(&structs.SchemaQuery{
+			Command: cmd.command,
+			Table:   cmd.blueprint.build(),
+			To:      cmd.to,
+		})
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmts...)
+	}
+	return statements, nil
+}
+
+// Blueprint collects the columns, indexes and foreign keys of a table.
+type Blueprint struct {
+	table           string
+	columns         []*ColumnDefinition
+	indexes         []structs.SchemaIndex
+	foreignKeys     []*ForeignKeyDefinition
+	dropColumns     []string
+	renameColumns   []structs.RenameColumn
+	dropIndexes     []string
+	dropForeignKeys []string
+}
+
+func NewBlueprint(table string) *Blueprint {
+	return &Blueprint{
+		table: table,
+	}
+}
+
+func (b *Blueprint) addColumn(column structs.SchemaColumn) *ColumnDefinition {
+	cd := &ColumnDefinition{column: column, blueprint: b}
+	b.columns = append(b.columns, cd)
+	return cd
+}
+
+// Increments adds an auto incrementing integer primary key.
+func (b *Blueprint) Increments(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_INCREMENTS})
+}
+
+// BigIncrements adds an auto incrementing big integer primary key.
+func (b *Blueprint) BigIncrements(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_BIG_INCREMENTS})
+}
+
+func (b *Blueprint) Integer(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_INTEGER})
+}
+
+func (b *Blueprint) BigInteger(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_BIG_INTEGER})
+}
+
+func (b *Blueprint) Boolean(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_BOOLEAN})
+}
+
+// String adds a VARCHAR column. The length defaults to 255.
+func (b *Blueprint) String(column string, length ...int) *ColumnDefinition {
+	l := consts.ColumnType_Default_String_Length
+	if len(length) > 0 {
+		l = length[0]
+	}
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_STRING, Length: l})
+}
+
+func (b *Blueprint) Text(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_TEXT})
+}
+
+func (b *Blueprint) JSON(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_JSON})
+}
+
+// JSONB adds a binary JSON column on PostgreSQL and a JSON column elsewhere.
+func (b *Blueprint) JSONB(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_JSONB})
+}
+
+func (b *Blueprint) Date(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_DATE})
+}
+
+func (b *Blueprint) Timestamp(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_TIMESTAMP})
+}
+
+func (b *Blueprint) TimestampTz(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_TIMESTAMP_TZ})
+}
+
+// Decimal adds a fixed point column. Precision and scale default to 8 and 2.
+func (b *Blueprint) Decimal(column string, precisionAndScale ...int) *ColumnDefinition {
+	precision, scale := consts.ColumnType_Default_Precision, consts.ColumnType_Default_Scale
+	if len(precisionAndScale) > 0 {
+		precision = precisionAndScale[0]
+	}
+	if len(precisionAndScale) > 1 {
+		scale = precisionAndScale[1]
+	}
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_DECIMAL, Precision: precision, Scale: scale})
+}
+
+func (b *Blueprint) UUID(column string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_UUID})
+}
+
+// Enum adds a column restricted to values. Dialects without an ENUM type use a
+// CHECK constraint.
+func (b *Blueprint) Enum(column string, values []string) *ColumnDefinition {
+	return b.addColumn(structs.SchemaColumn{Name: column, Type: consts.ColumnType_ENUM, Values: values})
+}
+
+func (b *Blueprint) DropColumn(columns ...string) *Blueprint {
+	b.dropColumns = append(b.dropColumns, columns...)
+	return b
+}
+
+func (b *Blueprint) RenameColumn(from string, to string) *Blueprint {
+	b.renameColumns = append(b.renameColumns, structs.RenameColumn{From: from, To: to})
+	return b
+}
+
+// Index adds an index. The name defaults to {table}_{columns}_index.
+func (b *Blueprint) Index(columns []string, name ...string) *Blueprint {
+	return b.addIndex(consts.Index_INDEX, columns, name)
+}
+
+// Unique adds a unique index. The name defaults to {table}_{columns}_unique.
+func (b *Blueprint) Unique(columns []string, name ...string) *Blueprint {
+	return b.addIndex(consts.Index_UNIQUE, columns, name)
+}
+
+// Fulltext adds a full text index on MySQL and a GIN index on PostgreSQL.
+func (b *Blueprint) Fulltext(columns []string, name ...string) *Blueprint {
+	return b.addIndex(consts.Index_FULLTEXT, columns, name)
+}
+
+// Primary sets a composite primary key.
+func (b *Blueprint) Primary(columns ...string) *Blueprint {
+	b.indexes = append(b.indexes, structs.SchemaIndex{Type: consts.Index_PRIMARY, Columns: columns})
+	return b
+}
+
+func (b *Blueprint) addIndex(typ string, columns []string, name []string) *Blueprint {
+	idx := structs.SchemaIndex{Type: typ, Columns: columns}
+	if len(name) > 0 && name[0] != "" {
+		idx.Name = name[0]
+	} else {
+		idx.Name = b.indexName(columns, typ)
+	}
+	b.indexes = append(b.indexes, idx)
+	return b
+}
+
+// Foreign adds a foreign key. The referenced table and columns are set on the
+// returned definition.
+func (b *Blueprint) Foreign(columns ...string) *ForeignKeyDefinition {
+	fk := &ForeignKeyDefinition{foreignKey: structs.ForeignKey{Columns: columns}}
+	b.foreignKeys = append(b.foreignKeys, fk)
+	return fk
+}
+
+func (b *Blueprint) DropIndex(name string) *Blueprint {
+	b.dropIndexes = append(b.dropIndexes, name)
+	return b
+}
+
+func (b *Blueprint) DropForeign(name string) *Blueprint {
+	b.dropForeignKeys = append(b.dropForeignKeys, name)
+	return b
+}
+
+func (b *Blueprint) indexName(columns []string, suffix string) string {
+	name := b.table + "_" + strings.Join(columns, "_") + "_" + suffix
+	return strings.ReplaceAll(strings.ToLower(name), ".", "_")
+}
+
+func (b *Blueprint) build() structs.SchemaTable {
+	t := structs.SchemaTable{
+		Name:            b.table,
+		Columns:         make([]structs.SchemaColumn, 0, len(b.columns)),
+		Indexes:         b.indexes,
+		ForeignKeys:     make([]structs.ForeignKey, 0, len(b.foreignKeys)),
+		DropColumns:     b.dropColumns,
+		RenameColumns:   b.renameColumns,
+		DropIndexes:     b.dropIndexes,
+		DropForeignKeys: b.dropForeignKeys,
+	}
+
+	for _, cd := range b.columns {
+		t.Columns = append(t.Columns, cd.column)
+	}
+	for _, fk := range b.foreignKeys {
+		f := fk.foreignKey
+		if f.Name == "" {
+			f.Name = b.indexName(f.Columns, "foreign")
+		}
+		t.ForeignKeys = append(t.ForeignKeys, f)
+	}
+
+	return t
+}
+
+// ColumnDefinition modifies the column it was returned for.
+type ColumnDefinition struct {
+	column    structs.SchemaColumn
+	blueprint *Blueprint
+}
+
+// Nullable allows NULL values. Columns are NOT NULL by default.
+func (c *ColumnDefinition) Nullable() *ColumnDefinition {
+	c.column.Nullable = true
+	return c
+}
+
+// Default sets a literal default value.
+func (c *ColumnDefinition) Default(value interface{}) *ColumnDefinition {
+	c.column.Default = value
+	c.column.HasDefault = true
+	return c
+}
+
+// DefaultRaw sets a default expression which is written as is.
+func (c *ColumnDefinition) DefaultRaw(expression string) *ColumnDefinition {
+	c.column.DefaultRaw = expression
+	return c
+}
+
+func (c *ColumnDefinition) Unsigned() *ColumnDefinition {
+	c.column.Unsigned = true
+	return c
+}
+
+func (c *ColumnDefinition) Primary() *ColumnDefinition {
+	c.column.Primary = true
+	return c
+}
+
+// Unique adds a unique index on the column.
+func (c *ColumnDefinition) Unique() *ColumnDefinition {
+	c.blueprint.Unique([]string{c.column.Name})
+	return c
+}
+
+// Index adds an index on the column.
+func (c *ColumnDefinition) Index() *ColumnDefinition {
+	c.blueprint.Index([]string{c.column.Name})
+	return c
+}
+
+type ForeignKeyDefinition struct {
+	foreignKey structs.ForeignKey
+}
+
+func (f *ForeignKeyDefinition) References(columns ...string) *ForeignKeyDefinition {
+	f.foreignKey.RefColumns = columns
+	return f
+}
+
+func (f *ForeignKeyDefinition) On(table string) *ForeignKeyDefinition {
+	f.foreignKey.RefTable = table
+	return f
+}
+
+func (f *ForeignKeyDefinition) OnDelete(action string) *ForeignKeyDefinition {
+	f.foreignKey.OnDelete = action
+	return f
+}
+
+func (f *ForeignKeyDefinition) OnUpdate(action string) *ForeignKeyDefinition {
+	f.foreignKey.OnUpdate = action
+	return f
+}
+
+func (f *ForeignKeyDefinition) Name(name string) *ForeignKeyDefinition {
+	f.foreignKey.Name = name
+	return f
+}
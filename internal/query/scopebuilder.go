@@ -0,0 +1,135 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+)
+
+// ErrUnknownScope is returned by Build when Scope names a scope that is not
+// registered for the table of the query.
+var ErrUnknownScope = errors.New("unknown scope")
+
+// ScopeBuilder collects the conditions of a scope.
+type ScopeBuilder struct {
+	*WhereBuilder[ScopeBuilder]
+	joinBuilder    *JoinBuilder[ScopeBuilder]
+	orderByBuilder *OrderByBuilder[ScopeBuilder]
+}
+
+func NewScopeBuilder(strategy interfaces.QueryBuilderStrategy) *ScopeBuilder {
+	sb := &ScopeBuilder{}
+
+	sb.WhereBuilder = NewWhereBuilder[ScopeBuilder](strategy)
+	sb.WhereBuilder.SetParent(sb)
+
+	// a scope only adds conditions; the join and order builders exist to
+	// satisfy the generic where builders of the api package
+	sb.joinBuilder = NewJoinBuilder[ScopeBuilder](strategy)
+	sb.joinBuilder.SetParent(sb)
+	sb.orderByBuilder = NewOrderByBuilder[ScopeBuilder](strategy)
+	sb.orderByBuilder.SetParent(sb)
+
+	return sb
+}
+
+// Groups returns the conditions of the scope.
+func (sb *ScopeBuilder) Groups() []structs.WhereGroup {
+	return sb.WhereBuilder.conditionGroups()
+}
+
+func (sb *ScopeBuilder) GetWhereBuilder() *WhereBuilder[ScopeBuilder] {
+	return sb.WhereBuilder
+}
+
+func (sb *ScopeBuilder) GetJoinBuilder() *JoinBuilder[ScopeBuilder] {
+	return sb.joinBuilder
+}
+
+func (sb *ScopeBuilder) GetOrderByBuilder() *OrderByBuilder[ScopeBuilder] {
+	return sb.orderByBuilder
+}
+
+// scopes holds the scopes a Select, Update or Delete builder applies.
+type scopes struct {
+	named       []scope.Func
+	without     []string
+	withoutAll  bool
+	onlyTrashed bool
+}
+
+// add looks up the named scopes of table.
+func (s *scopes) add(strategy interfaces.QueryBuilderStrategy, table string, names []string) error {
+	for _, name := range names {
+		fn, ok := strategy.Scopes().Named(table, name)
+		if !ok {
+			return fmt.Errorf("%w %q on table %q", ErrUnknownScope, name, table)
+		}
+		s.named = append(s.named, fn)
+	}
+	return nil
+}
+
+func (s *scopes) clone() scopes {
+	return scopes{
+		named:       slices.Clone(s.named),
+		without:     slices.Clone(s.without),
+		withoutAll:  s.withoutAll,
+		onlyTrashed: s.onlyTrashed,
+	}
+}
+
+// apply returns groups followed by the global scopes of table and the named
+// scopes, each as its own parenthesized group. groups are parenthesized when
+// they are joined with a top level OR, so a scope applies to every row.
+func (s *scopes) apply(strategy interfaces.QueryBuilderStrategy, table string, groups []structs.WhereGroup) []structs.WhereGroup {
+	var scoped [][]structs.WhereGroup
+	if !s.withoutAll {
+		for _, g := range strategy.Scopes().Globals(table) {
+			if slices.Contains(s.without, g.Name) {
+				continue
+			}
+			if g.Name == scope.SoftDeletes {
+				// the soft delete condition is qualified with the table
+				if groups, ok := strategy.Scopes().SoftDeleteGroups(table, false); ok {
+					if !s.onlyTrashed {
+						scoped = append(scoped, groups)
+					}
+					continue
+				}
+			}
+			scoped = append(scoped, g.Func())
+		}
+	}
+	if groups, ok := strategy.Scopes().SoftDeleteGroups(table, true); s.onlyTrashed && ok {
+		scoped = append(scoped, groups)
+	}
+	for _, fn := range s.named {
+		scoped = append(scoped, fn())
+	}
+	if len(scoped) == 0 {
+		return groups
+	}
+
+	// groups without conditions are dropped, as they would leave a dangling
+	// operator in front of the scopes
+	result := make([]structs.WhereGroup, 0, len(groups)+len(scoped))
+	for _, g := range groups {
+		if len(g.Conditions) > 0 {
+			result = append(result, g)
+		}
+	}
+	if structs.HasTopLevelOr(result) {
+		result = []structs.WhereGroup{structs.NestedGroup(result)}
+	}
+	for _, sg := range scoped {
+		if structs.HasConditions(sg) {
+			result = append(result, structs.NestedGroup(sg))
+		}
+	}
+	return result
+}
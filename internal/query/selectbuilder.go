@@ -3,6 +3,7 @@ package query
 import (
 	"sync"
 
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/memutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
@@ -16,7 +17,11 @@ type SelectBuilder struct {
 	*WhereBuilder[SelectBuilder]
 	*JoinBuilder[SelectBuilder]
 	*OrderByBuilder[SelectBuilder]
+	*WithBuilder[SelectBuilder]
 	BaseBuilder
+	cursor *cursorState
+	scopes scopes
+	err    error
 }
 
 func NewSelectBuilder(dbBuilder interfaces.QueryBuilderStrategy) *SelectBuilder {
@@ -57,6 +62,10 @@ func NewSelectBuilder(dbBuilder interfaces.QueryBuilderStrategy) *SelectBuilder
 	orderByBuilder.SetParent(b)
 	b.OrderByBuilder = orderByBuilder
 
+	withBuilder := NewWithBuilder[SelectBuilder](dbBuilder)
+	withBuilder.SetParent(b)
+	b.WithBuilder = withBuilder
+
 	return b
 }
 
@@ -79,6 +88,27 @@ func (b *SelectBuilder) Table(table string) *SelectBuilder {
 	return b
 }
 
+// Scope applies the named scopes registered for the table of the query. It
+// must be called after Table.
+func (b *SelectBuilder) Scope(names ...string) *SelectBuilder {
+	if err := b.scopes.add(b.dbBuilder, b.selectQuery.Table, names); err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (b *SelectBuilder) WithoutGlobalScope(names ...string) *SelectBuilder {
+	b.scopes.without = append(b.scopes.without, names...)
+	return b
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (b *SelectBuilder) WithoutGlobalScopes() *SelectBuilder {
+	b.scopes.withoutAll = true
+	return b
+}
+
 func (b *SelectBuilder) Select(columns ...string) *SelectBuilder {
 	for _, column := range columns {
 		*b.selectQuery.Columns = append(*b.selectQuery.Columns, structs.Column{Name: column})
@@ -150,6 +180,60 @@ func (b *SelectBuilder) Avg(column string) *SelectBuilder {
 	return b.aggregate(column, "AVG")
 }
 
+// SelectWindow adds a window function such as "ROW_NUMBER()" or "SUM(amount)"
+// to the select list. The window is configured through spec.
+func (b *SelectBuilder) SelectWindow(fn string, alias string, spec func(w *WindowBuilder)) *SelectBuilder {
+	name, args := parseWindowFunction(fn)
+
+	w := NewWindowBuilder()
+	if spec != nil {
+		spec(w)
+	}
+
+	*b.selectQuery.Columns = append(*b.selectQuery.Columns, structs.Column{
+		Name: alias,
+		Window: &structs.WindowFunction{
+			Function: name,
+			Args:     args,
+			Spec:     *w.Spec,
+		},
+	})
+	return b
+}
+
+// SelectWindowRaw adds a window function written as raw SQL, such as
+// "SUM(DISTINCT amount)", to the select list. The window is configured
+// through spec.
+func (b *SelectBuilder) SelectWindowRaw(raw string, alias string, spec func(w *WindowBuilder)) *SelectBuilder {
+	w := NewWindowBuilder()
+	if spec != nil {
+		spec(w)
+	}
+
+	*b.selectQuery.Columns = append(*b.selectQuery.Columns, structs.Column{
+		Name: alias,
+		Window: &structs.WindowFunction{
+			Raw:  raw,
+			Spec: *w.Spec,
+		},
+	})
+	return b
+}
+
+// Window adds a named window definition to the WINDOW clause.
+func (b *SelectBuilder) Window(name string, spec func(w *WindowBuilder)) *SelectBuilder {
+	w := NewWindowBuilder()
+	if spec != nil {
+		spec(w)
+	}
+
+	b.selectQuery.Windows = append(b.selectQuery.Windows, structs.NamedWindow{
+		Name: name,
+		Spec: *w.Spec,
+	})
+	return b
+}
+
 func (b *SelectBuilder) Distinct(column ...string) *SelectBuilder {
 	for i, c := range *b.selectQuery.Columns {
 		for _, col := range column {
@@ -266,16 +350,67 @@ func (b *SelectBuilder) LockForUpdate() *SelectBuilder {
 	return b
 }
 
+// GetLock returns the lock set by SharedLock or LockForUpdate.
+func (b *SelectBuilder) GetLock() *structs.Lock {
+	return b.selectQuery.Lock
+}
+
 // Build generates the SQL query string and parameter values based on the query builder's current state.
 // It returns the generated query string and a slice of parameter values.
 func (b *SelectBuilder) Build() (string, []interface{}, error) {
-	b.dbBuilder.ResetPlaceholderCounter()
+	if b.err != nil {
+		return "", nil, b.err
+	}
 
-	// last query to be built and add to the union
-	b.buildQuery()
+	q, err := b.cursorQuery()
+	if err != nil {
+		return "", nil, err
+	}
 
-	*b.selectQuery.Union = append(*b.selectQuery.Union, structs.Union{
-		Query: b.query,
+	return b.build(q)
+}
+
+// cursorQuery returns the query with the keyset condition of CursorPaginate.
+// The conditions of the query are parenthesized when they are joined with a
+// top level OR, so the keyset applies to every row.
+func (b *SelectBuilder) cursorQuery() (*structs.Query, error) {
+	keyset, err := b.cursorCondition()
+	if err != nil {
+		return nil, err
+	}
+
+	q := b.snapshot()
+	if keyset != nil {
+		groups := q.ConditionGroups[:len(q.ConditionGroups):len(q.ConditionGroups)]
+		if structs.HasTopLevelOr(groups) {
+			groups = []structs.WhereGroup{structs.NestedGroup(groups)}
+		}
+		q.ConditionGroups = append(groups, *keyset)
+	}
+	return q, nil
+}
+
+// build renders the unions followed by q, running the hooks of the strategy
+// on a copy of q.
+func (b *SelectBuilder) build(q *structs.Query) (string, []interface{}, error) {
+	hooks := b.dbBuilder.Hooks()
+	if hooks.Len() == 0 {
+		return b.render(q, *b.selectQuery.Union)
+	}
+
+	qc := *q
+	e := &hook.Event{Kind: hook.KindSelect, Select: &qc, Unions: *b.selectQuery.Union}
+	return runHooks(hooks, e, func() (string, []interface{}, error) {
+		return b.render(e.Select, e.Unions)
+	})
+}
+
+// render renders unions followed by q. unions are left unchanged, so a
+// builder and its clones can be rendered concurrently.
+func (b *SelectBuilder) render(q *structs.Query, unions []structs.Union) (string, []interface{}, error) {
+	n := len(unions)
+	unions = append(unions[:n:n], structs.Union{
+		Query: q,
 		IsAll: false,
 	})
 
@@ -286,15 +421,15 @@ func (b *SelectBuilder) Build() (string, []interface{}, error) {
 	}
 
 	estimatedSize := consts.StringBuffer_Short_Query_Grow
-	for i := range *b.selectQuery.Union {
-		if len((*b.selectQuery.Union)[i].Query.ConditionGroups) > 1 {
-			estimatedSize += len((*b.selectQuery.Union)[i].Query.ConditionGroups) * consts.StringBuffer_Where_Grow
+	for i := range unions {
+		if len(unions[i].Query.ConditionGroups) > 1 {
+			estimatedSize += len(unions[i].Query.ConditionGroups) * consts.StringBuffer_Where_Grow
 		}
-		if len(*(*b.selectQuery.Union)[i].Query.Columns) > 1 {
-			estimatedSize += len(*(*b.selectQuery.Union)[i].Query.Columns) * consts.StringBuffer_Column_Grow
+		if len(*unions[i].Query.Columns) > 1 {
+			estimatedSize += len(*unions[i].Query.Columns) * consts.StringBuffer_Column_Grow
 		}
-		if len(*(*b.selectQuery.Union)[i].Query.Joins.Joins) > 1 || len(*(*b.selectQuery.Union)[i].Query.Joins.JoinClauses) > 1 {
-			estimatedSize += len(*(*b.selectQuery.Union)[i].Query.Joins.Joins) * consts.StringBuffer_Join_Grow
+		if len(*unions[i].Query.Joins.Joins) > 1 || len(*unions[i].Query.Joins.JoinClauses) > 1 {
+			estimatedSize += len(*unions[i].Query.Joins.Joins) * consts.StringBuffer_Join_Grow
 		}
 	}
 	// grow the buffer if necessary; sb was reset above so no data to preserve
@@ -308,8 +443,10 @@ func (b *SelectBuilder) Build() (string, []interface{}, error) {
 		values = values[0:0]
 	}
 
-	for i := range *b.selectQuery.Union {
-		v, err := b.dbBuilder.Build(&sb, (*b.selectQuery.Union)[i].Query, i, b.selectQuery.Union)
+	strategy := b.dbBuilder.ForBuild()
+	defer release(strategy)
+	for i := range unions {
+		v, err := strategy.Build(&sb, unions[i].Query, i, &unions)
 		if err != nil {
 			return "", nil, err
 		}
@@ -320,9 +457,6 @@ func (b *SelectBuilder) Build() (string, []interface{}, error) {
 
 	retVals := append([]interface{}(nil), values...)
 
-	// remove the last UNION
-	*b.selectQuery.Union = (*b.selectQuery.Union)[:len(*b.selectQuery.Union)-1]
-
 	memutils.ZeroBytes(sb)
 	sb = sb[:0]
 	*ptr = sb
@@ -336,6 +470,110 @@ func (b *SelectBuilder) Build() (string, []interface{}, error) {
 	return query, retVals, nil
 }
 
+// BuildPage builds the query with the limit and offset replaced, leaving the
+// builder unchanged.
+func (b *SelectBuilder) BuildPage(limit, offset int64) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	q, err := b.cursorQuery()
+	if err != nil {
+		return "", nil, err
+	}
+
+	q.Limit = structs.Limit{Limit: limit}
+	q.Offset = structs.Offset{Offset: offset}
+	return b.build(q)
+}
+
+// BuildSelection builds the query with the columns and limit replaced,
+// leaving the builder unchanged. A nil columns or zero limit keeps the
+// current value.
+func (b *SelectBuilder) BuildSelection(columns []structs.Column, limit int64) (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	q, err := b.cursorQuery()
+	if err != nil {
+		return "", nil, err
+	}
+
+	if columns != nil {
+		q.Columns = &columns
+	}
+	if limit > 0 {
+		q.Limit = structs.Limit{Limit: limit}
+	}
+	return b.build(q)
+}
+
+// BuildCount builds a query counting the rows of the query without its ORDER
+// BY, LIMIT and OFFSET. Queries with GROUP BY, DISTINCT or UNION are counted as
+// a subquery; the others have their columns replaced by COUNT(*).
+func (b *SelectBuilder) BuildCount() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	q := b.snapshot()
+	q.Order = &[]structs.Order{}
+	q.Limit = structs.Limit{}
+	q.Offset = structs.Offset{}
+	q.Lock = &structs.Lock{}
+
+	if !b.needsCountSubquery(q) {
+		q.Columns = &[]structs.Column{{Raw: "COUNT(*)"}}
+		q.Windows = nil
+		return b.build(q)
+	}
+
+	query, values, err := b.build(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "SELECT COUNT(*) FROM (" + query + ") AS aggregate_table", values, nil
+}
+
+// BuildExists builds a query returning 1 when the query returns any row and 0
+// otherwise. The lock is dropped, and so is the ORDER BY unless a LIMIT or
+// OFFSET needs it, as neither is allowed in a subquery on every dialect.
+func (b *SelectBuilder) BuildExists() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+
+	q := b.snapshot()
+	if q.Limit.Limit == 0 && q.Offset.Offset == 0 {
+		q.Order = &[]structs.Order{}
+	}
+	q.Lock = &structs.Lock{}
+
+	query, values, err := b.build(q)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "SELECT CASE WHEN EXISTS (" + query + ") THEN 1 ELSE 0 END", values, nil
+}
+
+func (b *SelectBuilder) needsCountSubquery(q *structs.Query) bool {
+	if len(*b.selectQuery.Union) > 0 {
+		return true
+	}
+	if g := q.Group; g != nil && (len(g.Columns) > 0 || (g.Having != nil && len(*g.Having) > 0)) {
+		return true
+	}
+	for _, c := range *q.Columns {
+		if c.Distinct {
+			return true
+		}
+	}
+	return false
+}
+
 func (b *SelectBuilder) buildQuery() {
 	// preprocess WHERE
 	if len(*b.WhereBuilder.query.Conditions) > 0 {
@@ -362,11 +600,34 @@ func (b *SelectBuilder) buildQuery() {
 	b.query.Limit = b.selectQuery.Limit
 	b.query.Offset = b.selectQuery.Offset
 	b.query.Lock = b.selectQuery.Lock
-
+	b.query.With = *b.WithBuilder.CTEs
+	b.query.Windows = b.selectQuery.Windows
+
+}
+
+// snapshot returns the query to build. Unlike buildQuery it leaves the
+// builder unchanged, so Build can be called concurrently.
+func (b *SelectBuilder) snapshot() *structs.Query {
+	return &structs.Query{
+		Table:           structs.Table{Name: b.selectQuery.Table},
+		Columns:         b.selectQuery.Columns,
+		ConditionGroups: b.scopes.apply(b.dbBuilder, b.selectQuery.Table, b.WhereBuilder.conditionGroups()),
+		Joins:           b.JoinBuilder.Joins,
+		Order:           b.OrderByBuilder.Order,
+		Group:           b.selectQuery.Group,
+		Limit:           b.selectQuery.Limit,
+		Offset:          b.selectQuery.Offset,
+		Lock:            b.selectQuery.Lock,
+		With:            *b.WithBuilder.CTEs,
+		Windows:         b.selectQuery.Windows,
+	}
 }
 
+// GetQuery returns the query of the builder with its scopes applied, as
+// nested in a union, subquery or CTE.
 func (b *SelectBuilder) GetQuery() *structs.Query {
 	b.buildQuery()
+	b.query.ConditionGroups = b.scopes.apply(b.dbBuilder, b.selectQuery.Table, b.query.ConditionGroups)
 	return b.query
 }
 
@@ -0,0 +1,119 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/hook"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+)
+
+// ErrNoSoftDeletes is returned by Build for SoftDelete and Restore on a table
+// without a soft delete column.
+var ErrNoSoftDeletes = errors.New("table has no soft delete column")
+
+// how a DeleteBuilder removes the rows
+const (
+	deleteRows = iota
+	softDeleteRows
+	restoreRows
+)
+
+// WithTrashed includes the soft deleted rows.
+func (b *SelectBuilder) WithTrashed() *SelectBuilder {
+	b.scopes.without = append(b.scopes.without, scope.SoftDeletes)
+	return b
+}
+
+// OnlyTrashed selects only the soft deleted rows.
+func (b *SelectBuilder) OnlyTrashed() *SelectBuilder {
+	b.scopes.onlyTrashed = true
+	return b
+}
+
+// WithTrashed includes the soft deleted rows.
+func (b *UpdateBuilder) WithTrashed() *UpdateBuilder {
+	b.scopes.without = append(b.scopes.without, scope.SoftDeletes)
+	return b
+}
+
+// OnlyTrashed updates only the soft deleted rows.
+func (b *UpdateBuilder) OnlyTrashed() *UpdateBuilder {
+	b.scopes.onlyTrashed = true
+	return b
+}
+
+// WithTrashed includes the soft deleted rows.
+func (b *DeleteBuilder) WithTrashed() *DeleteBuilder {
+	b.scopes.without = append(b.scopes.without, scope.SoftDeletes)
+	return b
+}
+
+// OnlyTrashed deletes only the soft deleted rows.
+func (b *DeleteBuilder) OnlyTrashed() *DeleteBuilder {
+	b.scopes.onlyTrashed = true
+	return b
+}
+
+// SoftDelete builds an UPDATE setting the soft delete column of the rows that
+// are not deleted yet to the current time.
+func (b *DeleteBuilder) SoftDelete() *DeleteBuilder {
+	b.mode = softDeleteRows
+	return b
+}
+
+// ForceDelete builds a DELETE that also removes the soft deleted rows.
+func (b *DeleteBuilder) ForceDelete() *DeleteBuilder {
+	b.mode = deleteRows
+	return b.WithTrashed()
+}
+
+// Restore builds an UPDATE clearing the soft delete column of the soft
+// deleted rows.
+func (b *DeleteBuilder) Restore() *DeleteBuilder {
+	b.mode = restoreRows
+	return b.OnlyTrashed()
+}
+
+// buildSoftDelete renders the UPDATE of SoftDelete and Restore for q.
+func (d *DeleteBuilder) buildSoftDelete(q *structs.DeleteQuery) (string, []interface{}, error) {
+	column, ok := d.dbBuilder.Scopes().SoftDeleteColumn(q.Table)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %q", ErrNoSoftDeletes, q.Table)
+	}
+
+	// the clock is read once so the deleted and updated columns are equal
+	var now interface{} = time.Now()
+	opts, stamped := d.dbBuilder.Timestamps().Lookup(q.Table)
+	if stamped {
+		now = opts.Value()
+	}
+
+	values := map[string]interface{}{column: nil}
+	if d.mode == softDeleteRows {
+		values[column] = now
+	}
+	if stamped && opts.UpdatedAt != "" {
+		values = stamp(opts, now, values, false)
+	}
+	uq := &structs.UpdateQuery{
+		Table:     q.Table,
+		Values:    values,
+		Query:     q.Query,
+		Returning: q.Returning,
+		From:      q.Using,
+		LimitKey:  q.LimitKey,
+	}
+
+	hooks := d.dbBuilder.Hooks()
+	if hooks.Len() == 0 {
+		return buildUpdate(d.dbBuilder, uq)
+	}
+
+	e := &hook.Event{Kind: hook.KindUpdate, Update: uq}
+	return runHooks(hooks, e, func() (string, []interface{}, error) {
+		return buildUpdate(d.dbBuilder, e.Update)
+	})
+}
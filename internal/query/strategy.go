@@ -0,0 +1,34 @@
+package query
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// release returns a strategy taken from ForBuild to its pool.
+func release(strategy interfaces.QueryBuilderStrategy) {
+	if r, ok := strategy.(interfaces.Releaser); ok {
+		r.Release()
+	}
+}
+
+// buildInsert renders q with the strategy of one statement.
+func buildInsert(dbBuilder interfaces.QueryBuilderStrategy, q *structs.InsertQuery) (string, []interface{}, error) {
+	strategy := dbBuilder.ForBuild()
+	defer release(strategy)
+	return strategy.BuildInsert(q)
+}
+
+// buildUpdate renders q with the strategy of one statement.
+func buildUpdate(dbBuilder interfaces.QueryBuilderStrategy, q *structs.UpdateQuery) (string, []interface{}, error) {
+	strategy := dbBuilder.ForBuild()
+	defer release(strategy)
+	return strategy.BuildUpdate(q)
+}
+
+// buildDelete renders q with the strategy of one statement.
+func buildDelete(dbBuilder interfaces.QueryBuilderStrategy, q *structs.DeleteQuery) (string, []interface{}, error) {
+	strategy := dbBuilder.ForBuild()
+	defer release(strategy)
+	return strategy.BuildDelete(q)
+}
@@ -0,0 +1,80 @@
+package query
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
+)
+
+// stamp returns a copy of values with the timestamp columns set to now. The
+// created column is only set when created is true; columns set by the caller
+// are kept.
+func stamp(opts timestamp.Options, now interface{}, values map[string]interface{}, created bool) map[string]interface{} {
+	stamped := maps.Clone(values)
+	if stamped == nil {
+		stamped = make(map[string]interface{}, 2)
+	}
+	if created && opts.CreatedAt != "" {
+		if _, ok := stamped[opts.CreatedAt]; !ok {
+			stamped[opts.CreatedAt] = now
+		}
+	}
+	if opts.UpdatedAt != "" {
+		if _, ok := stamped[opts.UpdatedAt]; !ok {
+			stamped[opts.UpdatedAt] = now
+		}
+	}
+	return stamped
+}
+
+// withTimestamps returns a copy of q with the timestamp columns of its table
+// set in every row. An upsert also refreshes the updated column of the rows it
+// updates.
+func (ib *InsertBuilder) withTimestamps(q *structs.InsertQuery) *structs.InsertQuery {
+	opts, ok := ib.dbBuilder.Timestamps().Lookup(q.Table)
+	if !ok || q.Query != nil {
+		return q
+	}
+
+	now := opts.Value()
+	c := *q
+	if len(q.Values) > 0 {
+		c.Values = stamp(opts, now, q.Values, true)
+	}
+	if len(q.ValuesBatch) > 0 {
+		c.ValuesBatch = make([]map[string]interface{}, len(q.ValuesBatch))
+		for i, values := range q.ValuesBatch {
+			c.ValuesBatch[i] = stamp(opts, now, values, true)
+		}
+	}
+	if u := q.Upsert; u != nil && opts.UpdatedAt != "" && needsUpdatedAt(u, opts.UpdatedAt) {
+		c.Upsert = &structs.Upsert{
+			UniqueColumns: u.UniqueColumns,
+			UpdateColumns: append(slices.Clone(u.UpdateColumns), opts.UpdatedAt),
+			UpdateValues:  u.UpdateValues,
+		}
+	}
+	return &c
+}
+
+// needsUpdatedAt reports whether the upsert u updates rows without setting
+// the updated column.
+func needsUpdatedAt(u *structs.Upsert, column string) bool {
+	if len(u.UpdateColumns) == 0 && len(u.UpdateValues) == 0 {
+		return false
+	}
+	_, set := u.UpdateValues[column]
+	return !set && !slices.Contains(u.UpdateColumns, column)
+}
+
+// withUpdatedAt returns values with the updated column of table set.
+func withUpdatedAt(strategy interfaces.QueryBuilderStrategy, table string, values map[string]interface{}) map[string]interface{} {
+	opts, ok := strategy.Timestamps().Lookup(table)
+	if !ok || opts.UpdatedAt == "" {
+		return values
+	}
+	return stamp(opts, opts.Value(), values, false)
+}
@@ -1,17 +1,44 @@
 package query
 
 import (
-	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/faciam-dev/goquent-query-builder/hook"
 	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structutils"
 	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
 )
 
+// ErrNonNumericAmount is returned by Build for an increment or decrement by a
+// value that is not a number.
+var ErrNonNumericAmount = errors.New("increment amount is not a number")
+
+// ErrNoKey is returned by Build for UpdateStruct with a struct without pk
+// fields and no WHERE condition, which would update every row.
+var ErrNoKey = errors.New("struct has no pk field and the update no condition")
+
 type UpdateBuilder struct {
 	dbBuilder interfaces.QueryBuilderStrategy
 	query     *structs.UpdateQuery
 	OrderByBuilder[UpdateBuilder]
 	JoinBuilder[UpdateBuilder]
 	WhereBuilder[UpdateBuilder]
+	WithBuilder[UpdateBuilder]
+	scopes scopes
+	// unkeyed is set by UpdateStruct for a struct without pk fields
+	unkeyed bool
+	err     error
+}
+
+// UpdateStructOptions controls which fields UpdateStruct writes.
+type UpdateStructOptions struct {
+	// Columns restricts the SET clause to these columns.
+	Columns []string
+	// OmitZero skips every zero valued field, not only those tagged omitempty.
+	OmitZero bool
 }
 
 func NewUpdateBuilder(strategy interfaces.QueryBuilderStrategy) *UpdateBuilder {
@@ -34,6 +61,10 @@ func NewUpdateBuilder(strategy interfaces.QueryBuilderStrategy) *UpdateBuilder {
 	orderByBuilder.SetParent(ub)
 	ub.OrderByBuilder = *orderByBuilder
 
+	withBuilder := NewWithBuilder[UpdateBuilder](strategy)
+	withBuilder.SetParent(ub)
+	ub.WithBuilder = *withBuilder
+
 	return ub
 }
 
@@ -43,32 +74,185 @@ func (b *UpdateBuilder) Table(table string) *UpdateBuilder {
 	return b
 }
 
+// Scope applies the named scopes registered for the table of the query. It
+// must be called after Table.
+func (b *UpdateBuilder) Scope(names ...string) *UpdateBuilder {
+	if err := b.scopes.add(b.dbBuilder, b.query.Table, names); err != nil {
+		b.err = err
+	}
+	return b
+}
+
+// WithoutGlobalScope skips the named global scopes of the table.
+func (b *UpdateBuilder) WithoutGlobalScope(names ...string) *UpdateBuilder {
+	b.scopes.without = append(b.scopes.without, names...)
+	return b
+}
+
+// WithoutGlobalScopes skips every global scope of the table.
+func (b *UpdateBuilder) WithoutGlobalScopes() *UpdateBuilder {
+	b.scopes.withoutAll = true
+	return b
+}
+
+// Update sets the values of columns, keeping the other columns already set,
+// such as those of Increment.
 func (b *UpdateBuilder) Update(data map[string]interface{}) *UpdateBuilder {
-	b.query.Values = data
+	if len(b.query.Values) == 0 {
+		b.query.Values = data
+		return b
+	}
+
+	// the values are copied as a clone may share them
+	values := make(map[string]interface{}, len(b.query.Values)+len(data))
+	for column, value := range b.query.Values {
+		values[column] = value
+	}
+	for column, value := range data {
+		values[column] = value
+	}
+	b.query.Values = values
+
+	return b
+}
+
+// From adds a table the update reads from besides its target.
+func (b *UpdateBuilder) From(table string) *UpdateBuilder {
+	b.query.From = append(b.query.From, structs.Source{Name: table})
+	return b
+}
 
+// FromSub adds a subquery, named alias, the update reads from besides its
+// target.
+func (b *UpdateBuilder) FromSub(q *SelectBuilder, alias string) *UpdateBuilder {
+	b.query.From = append(b.query.From, structs.Source{Name: alias, Query: q.GetQuery()})
 	return b
 }
 
+// Increment adds amount to column. The extra values are updated alongside it.
+func (b *UpdateBuilder) Increment(column string, amount interface{}, extra ...map[string]interface{}) *UpdateBuilder {
+	return b.arithmetic("+", map[string]interface{}{column: amount}, extra)
+}
+
+// Decrement subtracts amount from column. The extra values are updated
+// alongside it.
+func (b *UpdateBuilder) Decrement(column string, amount interface{}, extra ...map[string]interface{}) *UpdateBuilder {
+	return b.arithmetic("-", map[string]interface{}{column: amount}, extra)
+}
+
+// IncrementEach adds each amount to its column. A negative amount decrements
+// the column.
+func (b *UpdateBuilder) IncrementEach(amounts map[string]interface{}) *UpdateBuilder {
+	return b.arithmetic("+", amounts, nil)
+}
+
+func (b *UpdateBuilder) arithmetic(operator string, amounts map[string]interface{}, extra []map[string]interface{}) *UpdateBuilder {
+	// the values are copied as a clone may share them
+	values := make(map[string]interface{}, len(b.query.Values)+len(amounts))
+	for column, value := range b.query.Values {
+		values[column] = value
+	}
+	for _, e := range extra {
+		for column, value := range e {
+			values[column] = value
+		}
+	}
+	for column, amount := range amounts {
+		if !isNumber(amount) {
+			b.err = fmt.Errorf("%w: %q", ErrNonNumericAmount, column)
+			return b
+		}
+		values[column] = structs.Arithmetic{Operator: operator, Amount: amount}
+	}
+	b.query.Values = values
+
+	return b
+}
+
+func isNumber(v interface{}) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// Limit limits the number of rows the statement updates.
+func (b *UpdateBuilder) Limit(limit int64) *UpdateBuilder {
+	b.query.Query.Limit.Limit = limit
+	return b
+}
+
+// LimitKey sets the column, usually the primary key, matching the limited
+// rows on dialects that emulate Limit with a subquery.
+func (b *UpdateBuilder) LimitKey(column string) *UpdateBuilder {
+	b.query.LimitKey = column
+	return b
+}
+
+// Returning sets the columns returned for the updated rows.
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b.query.Returning = columns
+	return b
+}
+
+// UpdateStruct sets the columns from the db tags of a struct. Fields tagged pk
+// are matched in the WHERE clause instead of being updated. Without pk fields
+// Build requires a Where condition.
+func (b *UpdateBuilder) UpdateStruct(v any, opts UpdateStructOptions) *UpdateBuilder {
+	values, keys, err := structutils.UpdateValues(v, opts.Columns, opts.OmitZero)
+	if err != nil {
+		b.err = err
+		return b
+	}
+
+	pks := make([]string, 0, len(keys))
+	for column := range keys {
+		pks = append(pks, column)
+	}
+	sort.Strings(pks)
+	b.unkeyed = len(pks) == 0
+	for _, column := range pks {
+		b.WhereBuilder.Where(column, "=", keys[column])
+	}
+
+	return b.Update(values)
+}
+
 func (u *UpdateBuilder) Build() (string, []interface{}, error) {
-	u.dbBuilder.ResetPlaceholderCounter()
-
-	// If there are conditions, add them to the query
-	if len(*u.WhereBuilder.query.Conditions) > 0 {
-		u.WhereBuilder.query.ConditionGroups = append(u.WhereBuilder.query.ConditionGroups, structs.WhereGroup{
-			Conditions:   *u.WhereBuilder.query.Conditions,
-			Operator:     consts.LogicalOperator_AND,
-			IsDummyGroup: true,
-		})
-		u.WhereBuilder.query.Conditions = &[]structs.Where{}
+	if u.err != nil {
+		return "", nil, u.err
 	}
+	groups := u.WhereBuilder.conditionGroups()
+	if u.unkeyed && !structs.HasConditions(groups) {
+		return "", nil, ErrNoKey
+	}
+
+	// the builder is left unchanged so it can be built concurrently
+	q, sq := *u.query, *u.query.Query
+	sq.Conditions = &[]structs.Where{}
+	sq.ConditionGroups = u.scopes.apply(u.dbBuilder, u.query.Table, groups)
+	sq.Joins = u.JoinBuilder.Joins
+	sq.Order = u.OrderByBuilder.Order
+	sq.With = *u.WithBuilder.CTEs
+	q.Query = &sq
+	q.Values = withUpdatedAt(u.dbBuilder, q.Table, q.Values)
 
-	u.query.Query.Conditions = u.WhereBuilder.query.Conditions
-	u.query.Query.ConditionGroups = u.WhereBuilder.query.ConditionGroups
-	u.query.Query.Joins = u.JoinBuilder.Joins
-	u.query.Query.Order = u.OrderByBuilder.Order
+	hooks := u.dbBuilder.Hooks()
+	if hooks.Len() == 0 {
+		return buildUpdate(u.dbBuilder, &q)
+	}
+
+	e := &hook.Event{Kind: hook.KindUpdate, Update: &q}
+	return runHooks(hooks, e, func() (string, []interface{}, error) {
+		return buildUpdate(u.dbBuilder, e.Update)
+	})
+}
 
-	query, values, err := u.dbBuilder.BuildUpdate(u.query)
-	return query, values, err
+func (b *UpdateBuilder) GetStrategy() interfaces.QueryBuilderStrategy {
+	return b.dbBuilder
 }
 
 func (b *UpdateBuilder) OrderBy(column string, direction string) *UpdateBuilder {
@@ -110,7 +110,7 @@ func (b *WhereBuilder[T]) whereOrOrWhereQuery(column string, condition string, q
 	*q.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: q.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: q.scopes.apply(q.dbBuilder, q.selectQuery.Table, q.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: q.selectQuery.Table},
 		Columns:         q.selectQuery.Columns,
 		Joins:           q.JoinBuilder.Joins,
@@ -305,7 +305,7 @@ func (b *WhereBuilder[T]) addWhereInSubQuery(column string, operator int, condit
 	*q.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: q.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: q.scopes.apply(q.dbBuilder, q.selectQuery.Table, q.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: q.selectQuery.Table},
 		Columns:         q.selectQuery.Columns,
 		Joins:           q.JoinBuilder.Joins,
@@ -514,7 +514,7 @@ func (b *WhereBuilder[T]) addWhereExists(fn func(aq *SelectBuilder), condition s
 	*nb.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: nb.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: nb.scopes.apply(nb.dbBuilder, nb.selectQuery.Table, nb.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: nb.selectQuery.Table},
 		Columns:         nb.selectQuery.Columns,
 		Joins:           nb.JoinBuilder.Joins,
@@ -565,7 +565,7 @@ func (b *WhereBuilder[T]) addWhereExistsQuery(q *SelectBuilder, condition string
 	*q.WhereBuilder.query.Conditions = []structs.Where{}
 
 	sq := &structs.Query{
-		ConditionGroups: q.WhereBuilder.query.ConditionGroups,
+		ConditionGroups: q.scopes.apply(q.dbBuilder, q.selectQuery.Table, q.WhereBuilder.query.ConditionGroups),
 		Table:           structs.Table{Name: q.selectQuery.Table},
 		Columns:         q.selectQuery.Columns,
 		Joins:           q.JoinBuilder.Joins,
@@ -778,6 +778,21 @@ func (b *WhereBuilder[T]) addWhereTime(column string, condition string, value st
 	return b.parent
 }
 
+// conditionGroups returns the condition groups with the pending conditions
+// appended as a group, leaving the builder unchanged.
+func (b *WhereBuilder[T]) conditionGroups() []structs.WhereGroup {
+	groups := b.query.ConditionGroups
+	if len(*b.query.Conditions) == 0 {
+		return groups
+	}
+
+	return append(groups[:len(groups):len(groups)], structs.WhereGroup{
+		Conditions:   *b.query.Conditions,
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	})
+}
+
 func (b *WhereBuilder[T]) GetQuery() *structs.Query {
 	return b.query
 }
@@ -0,0 +1,111 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+type WindowBuilder struct {
+	Spec *structs.WindowSpec
+}
+
+func NewWindowBuilder() *WindowBuilder {
+	return &WindowBuilder{
+		Spec: &structs.WindowSpec{},
+	}
+}
+
+// Window bases the specification on a window defined in the WINDOW clause.
+func (b *WindowBuilder) Window(name string) *WindowBuilder {
+	b.Spec.Name = name
+	return b
+}
+
+// PartitionBy adds PARTITION BY columns.
+func (b *WindowBuilder) PartitionBy(columns ...string) *WindowBuilder {
+	b.Spec.PartitionBy = append(b.Spec.PartitionBy, columns...)
+	return b
+}
+
+// OrderBy adds an ORDER BY column to the window.
+func (b *WindowBuilder) OrderBy(column string, ascDesc string) *WindowBuilder {
+	ascDesc = strings.ToUpper(ascDesc)
+
+	if ascDesc == consts.Order_ASC {
+		b.Spec.Order = append(b.Spec.Order, structs.Order{
+			Column: column,
+			IsAsc:  consts.Order_FLAG_ASC,
+		})
+	} else if ascDesc == consts.Order_DESC {
+		b.Spec.Order = append(b.Spec.Order, structs.Order{
+			Column: column,
+			IsAsc:  consts.Order_FLAG_DESC,
+		})
+	}
+	return b
+}
+
+// OrderByRaw adds a raw ORDER BY expression to the window.
+func (b *WindowBuilder) OrderByRaw(raw string) *WindowBuilder {
+	b.Spec.Order = append(b.Spec.Order, structs.Order{
+		Raw: raw,
+	})
+	return b
+}
+
+// RowsBetween sets a ROWS frame, e.g. RowsBetween("UNBOUNDED PRECEDING", "CURRENT ROW").
+func (b *WindowBuilder) RowsBetween(start string, end string) *WindowBuilder {
+	b.Spec.Frame = &structs.WindowFrame{
+		Unit:  consts.WindowFrame_ROWS,
+		Start: start,
+		End:   end,
+	}
+	return b
+}
+
+// RangeBetween sets a RANGE frame.
+func (b *WindowBuilder) RangeBetween(start string, end string) *WindowBuilder {
+	b.Spec.Frame = &structs.WindowFrame{
+		Unit:  consts.WindowFrame_RANGE,
+		Start: start,
+		End:   end,
+	}
+	return b
+}
+
+// parseWindowFunction splits "LAG(price, 1)" into the function name and its
+// arguments. A name without parentheses is treated as a call without
+// arguments.
+func parseWindowFunction(fn string) (string, []string) {
+	fn = strings.TrimSpace(fn)
+	open := strings.IndexByte(fn, '(')
+	if open < 0 || !strings.HasSuffix(fn, ")") {
+		return fn, nil
+	}
+
+	name := strings.TrimSpace(fn[:open])
+	inner := strings.TrimSpace(fn[open+1 : len(fn)-1])
+	if inner == "" {
+		return name, nil
+	}
+
+	args := make([]string, 0, 2)
+	inQuote := false
+	start := 0
+	for i := 0; i < len(inner); i++ {
+		switch inner[i] {
+		case '\'':
+			inQuote = !inQuote
+		case ',':
+			if !inQuote {
+				args = append(args, strings.TrimSpace(inner[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	args = append(args, strings.TrimSpace(inner[start:]))
+
+	return name, args
+}
@@ -0,0 +1,56 @@
+package query
+
+import (
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+type WithBuilder[T any] struct {
+	CTEs   *[]structs.CTE
+	parent *T
+}
+
+func NewWithBuilder[T any](strategy interfaces.QueryBuilderStrategy) *WithBuilder[T] {
+	return &WithBuilder[T]{
+		CTEs: &[]structs.CTE{},
+	}
+}
+
+func (b *WithBuilder[T]) SetParent(parent *T) *T {
+	b.parent = parent
+
+	return b.parent
+}
+
+// With adds a common table expression.
+func (b *WithBuilder[T]) With(name string, sb *SelectBuilder) *T {
+	*b.CTEs = append(*b.CTEs, structs.CTE{
+		Name:  name,
+		Query: sb.GetQuery(),
+	})
+	return b.parent
+}
+
+// WithMaterialized adds a common table expression with the MATERIALIZED hint.
+// The hint is only rendered by dialects that support it.
+func (b *WithBuilder[T]) WithMaterialized(name string, sb *SelectBuilder) *T {
+	*b.CTEs = append(*b.CTEs, structs.CTE{
+		Name:         name,
+		Query:        sb.GetQuery(),
+		Materialized: true,
+	})
+	return b.parent
+}
+
+// WithRecursive adds a recursive common table expression. The anchor and the
+// recursive part are combined with UNION ALL.
+func (b *WithBuilder[T]) WithRecursive(name string, columns []string, anchor *SelectBuilder, recursive *SelectBuilder) *T {
+	*b.CTEs = append(*b.CTEs, structs.CTE{
+		Name:           name,
+		Columns:        columns,
+		Query:          anchor.GetQuery(),
+		RecursiveQuery: recursive.GetQuery(),
+		Recursive:      true,
+	})
+	return b.parent
+}
@@ -0,0 +1,325 @@
+// Package migrate runs versioned migrations through database/sql. Applied
+// versions are recorded in a bookkeeping table created with the schema
+// builder of the configured strategy.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+const (
+	DefaultTable    = "migrations"
+	DefaultLockName = "goquent_migrations"
+)
+
+// unlockTimeout bounds the release of the advisory lock, which runs even when
+// the context of the migration is done.
+const unlockTimeout = 10 * time.Second
+
+var (
+	ErrNoVersion        = errors.New("migrate: migration has no version")
+	ErrNoUp             = errors.New("migrate: migration has no up function")
+	ErrDuplicateVersion = errors.New("migrate: duplicate migration version")
+	ErrNoDown           = errors.New("migrate: migration has no down function")
+	ErrUnknownVersion   = errors.New("migrate: applied migration is not registered")
+	ErrInvalidSteps     = errors.New("migrate: steps must be greater than zero")
+	ErrLocked           = errors.New("migrate: could not acquire the migration lock")
+	ErrNoDB             = errors.New("migrate: no database set")
+)
+
+// Migration is a versioned schema change. Migrations run in ascending order of
+// Version, so a timestamp prefix such as "20240101120000_create_users" is
+// recommended.
+type Migration struct {
+	Version string
+	Up      func(ctx context.Context, tx *Tx) error
+	Down    func(ctx context.Context, tx *Tx) error
+}
+
+// Status describes a registered migration.
+type Status struct {
+	Version string
+	Applied bool
+	// Batch is the run of Migrate that applied the migration, zero when pending.
+	Batch int64
+}
+
+type Migrator struct {
+	db         *sql.DB
+	strategy   interfaces.QueryBuilderStrategy
+	migrations []Migration
+	table      string
+	lockName   string
+	dryRun     io.Writer
+	err        error
+}
+
+func New(db *sql.DB, strategy interfaces.QueryBuilderStrategy) *Migrator {
+	return &Migrator{
+		db:         db,
+		strategy:   strategy,
+		migrations: make([]Migration, 0),
+		table:      DefaultTable,
+		lockName:   DefaultLockName,
+	}
+}
+
+// Register adds migrations. Errors are reported by the next command.
+func (m *Migrator) Register(migrations ...Migration) *Migrator {
+	for _, mg := range migrations {
+		switch {
+		case mg.Version == "":
+			m.err = ErrNoVersion
+		case mg.Up == nil:
+			m.err = fmt.Errorf("%w: %s", ErrNoUp, mg.Version)
+		case m.find(mg.Version) != nil:
+			m.err = fmt.Errorf("%w: %s", ErrDuplicateVersion, mg.Version)
+		}
+		if m.err != nil {
+			return m
+		}
+		m.migrations = append(m.migrations, mg)
+	}
+
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+	return m
+}
+
+// SetTable sets the name of the bookkeeping table.
+func (m *Migrator) SetTable(table string) *Migrator {
+	m.table = table
+	return m
+}
+
+// SetLockName sets the name of the advisory lock.
+func (m *Migrator) SetLockName(name string) *Migrator {
+	m.lockName = name
+	return m
+}
+
+// SetDryRun writes the statements of every step to w instead of running them.
+// Nothing is written to the database and no lock is taken. The applied
+// versions are still read when a database is set, so the bookkeeping table
+// must exist; without a database every migration is treated as pending.
+func (m *Migrator) SetDryRun(w io.Writer) *Migrator {
+	m.dryRun = w
+	return m
+}
+
+// Migrate applies every pending migration and returns their versions. The
+// migrations of one call share a batch number.
+func (m *Migrator) Migrate(ctx context.Context) ([]string, error) {
+	var done []string
+	err := m.run(ctx, func(s *session) error {
+		applied, batch, err := s.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		batch++
+		for _, mg := range m.migrations {
+			if _, ok := applied[mg.Version]; ok {
+				continue
+			}
+			if err := s.apply(ctx, mg, true, batch); err != nil {
+				return err
+			}
+			done = append(done, mg.Version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Rollback reverts the last steps migrations, newest first, and returns their
+// versions.
+func (m *Migrator) Rollback(ctx context.Context, steps int) ([]string, error) {
+	if steps < 1 {
+		return nil, ErrInvalidSteps
+	}
+	return m.rollback(ctx, steps)
+}
+
+// Reset reverts every applied migration.
+func (m *Migrator) Reset(ctx context.Context) ([]string, error) {
+	return m.rollback(ctx, -1)
+}
+
+// Status returns every registered migration with its applied state.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	s := &session{m: m}
+	if m.db != nil {
+		s.q = m.db
+		if m.dryRun == nil {
+			if err := s.createTable(ctx); err != nil {
+				return nil, err
+			}
+		}
+	} else if m.dryRun == nil {
+		return nil, ErrNoDB
+	}
+
+	applied, _, err := s.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, mg := range m.migrations {
+		batch, ok := applied[mg.Version]
+		statuses = append(statuses, Status{Version: mg.Version, Applied: ok, Batch: batch})
+	}
+	return statuses, nil
+}
+
+func (m *Migrator) rollback(ctx context.Context, steps int) ([]string, error) {
+	var done []string
+	err := m.run(ctx, func(s *session) error {
+		applied, _, err := s.applied(ctx)
+		if err != nil {
+			return err
+		}
+
+		versions := make([]string, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		sort.Slice(versions, func(i, j int) bool {
+			if applied[versions[i]] != applied[versions[j]] {
+				return applied[versions[i]] > applied[versions[j]]
+			}
+			return versions[i] > versions[j]
+		})
+		if steps > 0 && steps < len(versions) {
+			versions = versions[:steps]
+		}
+
+		for _, version := range versions {
+			mg := m.find(version)
+			if mg == nil {
+				return fmt.Errorf("%w: %s", ErrUnknownVersion, version)
+			}
+			if mg.Down == nil {
+				return fmt.Errorf("%w: %s", ErrNoDown, version)
+			}
+			if err := s.apply(ctx, *mg, false, 0); err != nil {
+				return err
+			}
+			done = append(done, version)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// run holds the advisory lock on a single connection while fn runs.
+func (m *Migrator) run(ctx context.Context, fn func(s *session) error) (err error) {
+	if m.err != nil {
+		return m.err
+	}
+
+	if m.dryRun != nil {
+		s := &session{m: m}
+		if m.db != nil {
+			s.q = m.db
+		}
+		return fn(s)
+	}
+
+	if m.db == nil {
+		return ErrNoDB
+	}
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.lock(ctx, conn); err != nil {
+		return err
+	}
+	defer func() {
+		if uerr := m.unlock(ctx, conn); uerr != nil && err == nil {
+			err = uerr
+		}
+	}()
+
+	s := &session{m: m, q: conn, conn: conn}
+	if err := s.createTable(ctx); err != nil {
+		return err
+	}
+	return fn(s)
+}
+
+// lock takes a session level advisory lock on MySQL and PostgreSQL. Other
+// dialects run without a lock.
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) error {
+	switch m.strategy.Dialect() {
+	case consts.DialectMySQL:
+		var got sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", m.lockName).Scan(&got); err != nil {
+			return err
+		}
+		if !got.Valid || got.Int64 != 1 {
+			return ErrLocked
+		}
+	case consts.DialectPostgreSQL:
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", m.lockKey()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unlock releases the advisory lock, also when ctx is done. When it fails the
+// connection is discarded rather than returned to the pool holding the lock.
+func (m *Migrator) unlock(ctx context.Context, conn *sql.Conn) error {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), unlockTimeout)
+	defer cancel()
+
+	var err error
+	switch m.strategy.Dialect() {
+	case consts.DialectMySQL:
+		_, err = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", m.lockName)
+	case consts.DialectPostgreSQL:
+		_, err = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", m.lockKey())
+	}
+	if err != nil {
+		conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	return err
+}
+
+// lockKey maps the lock name to the bigint key of pg_advisory_lock.
+func (m *Migrator) lockKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(m.lockName))
+	return int64(h.Sum64())
+}
+
+func (m *Migrator) find(version string) *Migration {
+	for i := range m.migrations {
+		if m.migrations[i].Version == version {
+			return &m.migrations[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/executor"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// ErrDryRunQuery is returned by queries that read rows in dry-run mode.
+var ErrDryRunQuery = errors.New("migrate: queries cannot run in dry-run mode")
+
+// Tx is passed to the Up and Down functions of a migration. Every migration
+// runs in its own transaction, which also records the migration in the
+// bookkeeping table. MySQL commits DDL statements implicitly, so a failing
+// migration may be partially applied there.
+type Tx struct {
+	q        executor.Queryer
+	strategy interfaces.QueryBuilderStrategy
+}
+
+// Strategy returns the strategy configured on the Migrator.
+func (t *Tx) Strategy() interfaces.QueryBuilderStrategy {
+	return t.strategy
+}
+
+// Queryer returns the transaction, e.g. for SetQueryer on a builder. In
+// dry-run mode statements are written out and reading rows fails with
+// ErrDryRunQuery.
+func (t *Tx) Queryer() executor.Queryer {
+	return t.q
+}
+
+// Exec runs a statement in the transaction.
+func (t *Tx) Exec(ctx context.Context, query string, args ...interface{}) error {
+	_, err := t.q.ExecContext(ctx, query, args...)
+	return err
+}
+
+// Schema runs the statements of the schema builder configured by fn.
+func (t *Tx) Schema(ctx context.Context, fn func(s *api.SchemaBuilder)) error {
+	sb := api.NewSchemaBuilder(t.strategy)
+	fn(sb)
+
+	statements, err := sb.Build()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range statements {
+		if err := t.Exec(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dryRunQueryer writes statements to w instead of running them.
+type dryRunQueryer struct {
+	w io.Writer
+}
+
+func (d dryRunQueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, ErrDryRunQuery
+}
+
+func (d dryRunQueryer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var err error
+	if len(args) > 0 {
+		_, err = fmt.Fprintf(d.w, "%s; -- %v\n", query, args)
+	} else {
+		_, err = fmt.Fprintf(d.w, "%s;\n", query)
+	}
+	return driver.RowsAffected(0), err
+}
+
+// session is one locked run of the Migrator. q is nil in dry-run mode without
+// a database.
+type session struct {
+	m    *Migrator
+	q    executor.Queryer
+	conn *sql.Conn
+}
+
+func (s *session) createTable(ctx context.Context) error {
+	statements, err := api.NewSchemaBuilder(s.m.strategy).
+		CreateTableIfNotExists(s.m.table, func(t *api.Blueprint) {
+			t.String("version").Primary()
+			t.BigInteger("batch")
+		}).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	for _, stmt := range statements {
+		if _, err := executor.Exec(ctx, s.q, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applied returns the batch of every applied version and the last batch.
+func (s *session) applied(ctx context.Context) (map[string]int64, int64, error) {
+	applied := make(map[string]int64)
+	if s.q == nil {
+		return applied, 0, nil
+	}
+
+	query, values, err := api.NewSelectQueryBuilder(s.m.strategy).
+		Table(s.m.table).
+		Select("version", "batch").
+		Build()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := executor.Query(ctx, s.q, query, values...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var last int64
+	for _, row := range rows {
+		batch, err := executor.ToInt64(row["batch"])
+		if err != nil {
+			return nil, 0, err
+		}
+		applied[fmt.Sprint(row["version"])] = batch
+		if batch > last {
+			last = batch
+		}
+	}
+	return applied, last, nil
+}
+
+// apply runs one direction of a migration and updates the bookkeeping table in
+// the same transaction.
+func (s *session) apply(ctx context.Context, mg Migration, up bool, batch int64) (err error) {
+	fn, direction := mg.Up, "up"
+	if !up {
+		fn, direction = mg.Down, "down"
+	}
+
+	var record string
+	var values []interface{}
+	if up {
+		record, values, err = api.NewInsertQueryBuilder(s.m.strategy).
+			Table(s.m.table).
+			Insert(map[string]interface{}{"version": mg.Version, "batch": batch}).
+			Build()
+	} else {
+		record, values, err = api.NewDeleteQueryBuilder(s.m.strategy).
+			Table(s.m.table).
+			Where("version", "=", mg.Version).
+			Build()
+	}
+	if err != nil {
+		return err
+	}
+
+	if s.m.dryRun != nil {
+		if _, err := fmt.Fprintf(s.m.dryRun, "-- %s: %s\n", mg.Version, direction); err != nil {
+			return err
+		}
+		tx := &Tx{q: dryRunQueryer{w: s.m.dryRun}, strategy: s.m.strategy}
+		if err := fn(ctx, tx); err != nil {
+			return fmt.Errorf("migrate: %s %s: %w", mg.Version, direction, err)
+		}
+		return tx.Exec(ctx, record, values...)
+	}
+
+	sqlTx, err := s.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = sqlTx.Rollback()
+		}
+	}()
+
+	tx := &Tx{q: sqlTx, strategy: s.m.strategy}
+	if err := fn(ctx, tx); err != nil {
+		return fmt.Errorf("migrate: %s %s: %w", mg.Version, direction, err)
+	}
+	if err := tx.Exec(ctx, record, values...); err != nil {
+		return err
+	}
+	return sqlTx.Commit()
+}
@@ -0,0 +1,175 @@
+// Package scope keeps reusable conditions by table. A Registry belongs to a
+// strategy instance: named scopes are applied to a query with Scope, global
+// scopes are applied to every select, update and delete on their table unless
+// the query opts out with WithoutGlobalScope.
+package scope
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+// SoftDeletes is the name of the global scope registered by
+// RegisterSoftDeletes. It hides the rows whose soft delete column is set.
+const SoftDeletes = "soft_deletes"
+
+// Func returns the conditions of a scope. It is called every time a query
+// using the scope is built.
+type Func func() []structs.WhereGroup
+
+// Global is a global scope of a table.
+type Global struct {
+	Name string
+	Func Func
+}
+
+// Registry holds the named and global scopes of a strategy. It is safe for
+// concurrent use.
+type Registry struct {
+	mu          sync.RWMutex
+	named       map[string]map[string]Func
+	global      map[string][]Global
+	softDeletes map[string]string
+}
+
+// Register adds a named scope to table, replacing a scope of the same name.
+func (r *Registry) Register(table, name string, fn Func) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.named == nil {
+		r.named = make(map[string]map[string]Func)
+	}
+	if r.named[table] == nil {
+		r.named[table] = make(map[string]Func)
+	}
+	r.named[table][name] = fn
+}
+
+// RegisterGlobal adds a global scope to table, replacing a global scope of the
+// same name. Global scopes are applied in the order they are registered.
+func (r *Registry) RegisterGlobal(table, name string, fn Func) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.global == nil {
+		r.global = make(map[string][]Global)
+	}
+	// the slice returned by Globals is never modified
+	scopes := slices.Clone(r.global[table])
+	for i, g := range scopes {
+		if g.Name == name {
+			scopes[i].Func = fn
+			r.global[table] = scopes
+			return
+		}
+	}
+	r.global[table] = append(scopes, Global{Name: name, Func: fn})
+}
+
+// RegisterSoftDeletes marks table as soft deleted through column, a nullable
+// timestamp, and registers the SoftDeletes global scope that hides the rows
+// where it is set.
+func (r *Registry) RegisterSoftDeletes(table, column string) {
+	r.mu.Lock()
+	if r.softDeletes == nil {
+		r.softDeletes = make(map[string]string)
+	}
+	r.softDeletes[table] = column
+	r.mu.Unlock()
+
+	r.RegisterGlobal(table, SoftDeletes, func() []structs.WhereGroup {
+		return nullGroups(column, consts.Condition_IS_NULL)
+	})
+}
+
+// SoftDeleteColumn returns the soft delete column of the table a query is
+// built on.
+func (r *Registry) SoftDeleteColumn(table string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	column, ok := r.softDeletes[TableName(table)]
+	return column, ok
+}
+
+// SoftDeleteGroups returns the condition on the soft delete column of the
+// table a query is built on, matching the soft deleted rows when trashed is
+// set and the others otherwise. The column is qualified with the alias of
+// table, or its name, so it stays unambiguous in a join.
+func (r *Registry) SoftDeleteGroups(table string, trashed bool) ([]structs.WhereGroup, bool) {
+	column, ok := r.SoftDeleteColumn(table)
+	if !ok {
+		return nil, false
+	}
+
+	condition := consts.Condition_IS_NULL
+	if trashed {
+		condition = consts.Condition_IS_NOT_NULL
+	}
+	return nullGroups(Qualifier(table)+"."+column, condition), true
+}
+
+// nullGroups returns the groups of WhereNull(column) or WhereNotNull(column).
+func nullGroups(column, condition string) []structs.WhereGroup {
+	return []structs.WhereGroup{{
+		Conditions: []structs.Where{{
+			Column:    column,
+			Condition: condition,
+			Operator:  consts.LogicalOperator_AND,
+		}},
+		Operator:     consts.LogicalOperator_AND,
+		IsDummyGroup: true,
+	}}
+}
+
+// Named returns the named scope of the table a query is built on. table may
+// carry an alias, as in "users AS u".
+func (r *Registry) Named(table, name string) (Func, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fn, ok := r.named[TableName(table)][name]
+	return fn, ok
+}
+
+// Globals returns the global scopes of the table a query is built on.
+func (r *Registry) Globals(table string) []Global {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.global[TableName(table)]
+}
+
+// TableName strips the alias from table.
+func TableName(table string) string {
+	if i := strings.IndexByte(table, ' '); i >= 0 {
+		return table[:i]
+	}
+	return table
+}
+
+// Qualifier returns the name the columns of table are qualified with: its
+// alias, as in "users AS u", or its name.
+func Qualifier(table string) string {
+	if i := strings.LastIndexByte(table, ' '); i >= 0 {
+		return table[i+1:]
+	}
+	return table
+}
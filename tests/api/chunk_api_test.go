@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestChunkApi(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() ([]api.Statement, error)
+		expected []api.Statement
+	}{
+		{
+			"InsertBatchChunked",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					InsertBatchChunked([]map[string]interface{}{
+						{"name": "A", "age": 1},
+						{"name": "B"},
+						{"name": "C", "age": 3},
+					}, api.ChunkOptions{MaxParams: 4})
+			},
+			[]api.Statement{
+				{Query: `INSERT INTO "users" ("age", "name") VALUES ($1, $2), ($3, $4)`, Values: []interface{}{1, "A", nil, "B"}},
+				{Query: `INSERT INTO "users" ("age", "name") VALUES ($1, $2)`, Values: []interface{}{3, "C"}},
+			},
+		},
+		{
+			"Upsert",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					Upsert([]map[string]interface{}{{"email": "a@example.com"}, {"email": "b@example.com"}}, []string{"email"}, nil).
+					UpsertSet(map[string]interface{}{"visits": api.Raw("`visits` + ?", 1)}).
+					BuildChunked(api.ChunkOptions{MaxParams: 2})
+			},
+			[]api.Statement{
+				{Query: "INSERT INTO `users` (`email`) VALUES (?) ON DUPLICATE KEY UPDATE `visits` = `visits` + ?", Values: []interface{}{"a@example.com", 1}},
+				{Query: "INSERT INTO `users` (`email`) VALUES (?) ON DUPLICATE KEY UPDATE `visits` = `visits` + ?", Values: []interface{}{"b@example.com", 1}},
+			},
+		},
+		{
+			"InsertOrIgnoreMaxRows",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("tags").
+					InsertOrIgnore([]map[string]interface{}{{"name": "a"}, {"name": "b"}, {"name": "c"}}).
+					BuildChunked(api.ChunkOptions{MaxRows: 2})
+			},
+			[]api.Statement{
+				{Query: `INSERT INTO "tags" ("name") VALUES ($1), ($2) ON CONFLICT DO NOTHING`, Values: []interface{}{"a", "b"}},
+				{Query: `INSERT INTO "tags" ("name") VALUES ($1) ON CONFLICT DO NOTHING`, Values: []interface{}{"c"}},
+			},
+		},
+		{
+			"MaxBytes",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("docs").
+					InsertBatchChunked([]map[string]interface{}{{"body": "0123456789"}, {"body": "0123456789"}}, api.ChunkOptions{MaxBytes: 30})
+			},
+			[]api.Statement{
+				{Query: `INSERT INTO "docs" ("body") VALUES ($1)`, Values: []interface{}{"0123456789"}},
+				{Query: `INSERT INTO "docs" ("body") VALUES ($1)`, Values: []interface{}{"0123456789"}},
+			},
+		},
+		{
+			"NoRows",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					InsertBatchChunked(nil, api.ChunkOptions{})
+			},
+			[]api.Statement{},
+		},
+		{
+			"Single",
+			func() ([]api.Statement, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Insert(map[string]interface{}{"name": "A"}).
+					BuildChunked(api.ChunkOptions{})
+			},
+			[]api.Statement{
+				{Query: `INSERT INTO "users" ("name") VALUES ($1)`, Values: []interface{}{"A"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(statements, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, statements)
+			}
+		})
+	}
+}
+
+func TestChunkApiDialectDefaults(t *testing.T) {
+	rows := make([]map[string]interface{}, 2500)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"id": i}
+	}
+
+	statements, err := api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("items").
+		InsertBatchChunked(rows, api.ChunkOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statements) != 3 {
+		t.Fatalf("expected 3 statements but got %d", len(statements))
+	}
+	for i, expected := range []int{1000, 1000, 500} {
+		if len(statements[i].Values) != expected {
+			t.Errorf("expected %d values in statement %d but got %d", expected, i, len(statements[i].Values))
+		}
+	}
+
+	_, err = api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("items").
+		InsertBatchChunked([]map[string]interface{}{{"a": 1, "b": 2}}, api.ChunkOptions{MaxParams: 1})
+	if !errors.Is(err, api.ErrRowTooLarge) {
+		t.Errorf("expected ErrRowTooLarge but got %v", err)
+	}
+}
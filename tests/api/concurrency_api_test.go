@@ -0,0 +1,274 @@
+package api_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+// Strategies shared by every goroutine, as package level singletons.
+var (
+	sharedPostgreSQL = postgres.NewPostgreSQLQueryBuilder()
+	sharedSQLServer  = sqlserver.NewSQLServerQueryBuilder()
+)
+
+// TestConcurrentBuild extends one base query from many goroutines; run it
+// with -race.
+func TestConcurrentBuild(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy interfaces.QueryBuilderStrategy
+		expected string
+	}{
+		{
+			"PostgreSQL",
+			sharedPostgreSQL,
+			`SELECT "id", "name" FROM "admins" WHERE "level" = $1 UNION SELECT "id", "name" FROM "users" WHERE "active" = $2 AND "age" > $3 AND "id" IN (SELECT "user_id" FROM "orders" WHERE "total" > $4) ORDER BY "id" ASC LIMIT 10`,
+		},
+		{
+			"SQLServer",
+			sharedSQLServer,
+			`SELECT [id], [name] FROM [admins] WHERE [level] = @p1 UNION SELECT TOP (10) [id], [name] FROM [users] WHERE [active] = @p2 AND [age] > @p3 AND [id] IN (SELECT [user_id] FROM [orders] WHERE [total] > @p4) ORDER BY [id] ASC`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			admins := api.NewSelectQueryBuilder(tt.strategy).Table("admins").Select("id", "name").Where("level", "=", 3)
+			base := api.NewSelectQueryBuilder(tt.strategy).Table("users").Select("id", "name").Where("active", "=", true).Union(admins)
+
+			var wg sync.WaitGroup
+			for i := 0; i < 100; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+
+					orders := api.NewSelectQueryBuilder(tt.strategy).Table("orders").Select("user_id").Where("total", ">", i*10)
+					q := base.Clone().Where("age", ">", i).WhereIn("id", orders).OrderBy("id", "asc").Limit(10)
+
+					// the clone itself is also built concurrently
+					var inner sync.WaitGroup
+					for j := 0; j < 2; j++ {
+						inner.Add(1)
+						go func() {
+							defer inner.Done()
+							query, values, err := q.Build()
+							if err != nil {
+								t.Errorf("unexpected error: %v", err)
+								return
+							}
+							if query != tt.expected {
+								t.Errorf("expected '%s' but got '%s'", tt.expected, query)
+							}
+							if expected := []interface{}{3, true, i, i * 10}; !reflect.DeepEqual(values, expected) {
+								t.Errorf("expected values %v but got %v", expected, values)
+							}
+						}()
+					}
+					inner.Wait()
+				}(i)
+			}
+			wg.Wait()
+
+			// the base query is unchanged
+			query, values, err := base.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(values, []interface{}{3, true}) {
+				t.Errorf("base query was modified: '%s' %v", query, values)
+			}
+		})
+	}
+}
+
+// TestSharedStrategyJoin builds the same join twice with one strategy; the
+// tables selected for the joins of the first statement must not leak into
+// the second.
+func TestSharedStrategyJoin(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy interfaces.QueryBuilderStrategy
+		expected string
+	}{
+		{
+			"MySQL",
+			mysql.NewMySQLQueryBuilder(),
+			"SELECT `posts`.*, `users`.* FROM `users` INNER JOIN `posts` ON `users`.`id` = `posts`.`user_id`",
+		},
+		{
+			"PostgreSQL",
+			sharedPostgreSQL,
+			`SELECT "posts".*, "users".* FROM "users" INNER JOIN "posts" ON "users"."id" = "posts"."user_id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				query, _, err := api.NewSelectQueryBuilder(tt.strategy).Table("users").
+					Join("posts", "users.id", "=", "posts.user_id").
+					Build()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if query != tt.expected {
+					t.Errorf("build %d: expected '%s' but got '%s'", i+1, tt.expected, query)
+				}
+			}
+		})
+	}
+}
+
+// TestConcurrentTerminalMethods runs the terminal methods replacing the
+// selection of one shared builder while it is built; run it with -race.
+func TestConcurrentTerminalMethods(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+	ctx := context.Background()
+
+	base := api.NewSelectQueryBuilder(sharedPostgreSQL).SetQueryer(db).Table("users").Where("active", "=", true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		// an empty result for the Pluck and the First
+		rec.AddRows([]string{"name"})
+		rec.AddRows([]string{"name"})
+
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			if _, err := base.Pluck(ctx, "name"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := base.First(ctx); !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("expected sql.ErrNoRows but got %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			query, _, err := base.Build()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if expected := `SELECT * FROM "users" WHERE "active" = $1`; query != expected {
+				t.Errorf("expected '%s' but got '%s'", expected, query)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, call := range rec.Calls() {
+		switch call.Query {
+		case `SELECT "name" FROM "users" WHERE "active" = $1`, `SELECT * FROM "users" WHERE "active" = $1 LIMIT 1`:
+		default:
+			t.Errorf("unexpected query '%s'", call.Query)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	strategy := sharedPostgreSQL
+
+	tests := []struct {
+		name  string
+		build func() (original, clone interface {
+			Build() (string, []interface{}, error)
+		})
+		expectedOriginal string
+		expectedClone    string
+	}{
+		{
+			"Select",
+			func() (interface {
+				Build() (string, []interface{}, error)
+			}, interface {
+				Build() (string, []interface{}, error)
+			}) {
+				q := api.NewSelectQueryBuilder(strategy).Table("users").Where("id", ">", 1).GroupBy("role").Having("role", "!=", "guest")
+				c := q.Clone().Where("age", ">", 18).Having("role", "!=", "bot").Join("posts", "users.id", "=", "posts.user_id").SelectCount("role")
+				return q, c
+			},
+			`SELECT * FROM "users" WHERE "id" > $1 GROUP BY "role" HAVING "role" != $2`,
+			`SELECT COUNT("role") FROM "users" INNER JOIN "posts" ON "users"."id" = "posts"."user_id" WHERE "id" > $1 AND "age" > $2 GROUP BY "role" HAVING "role" != $3 AND "role" != $4`,
+		},
+		{
+			"Insert",
+			func() (interface {
+				Build() (string, []interface{}, error)
+			}, interface {
+				Build() (string, []interface{}, error)
+			}) {
+				q := api.NewInsertQueryBuilder(strategy).Table("users").Insert(map[string]interface{}{"name": "John"})
+				c := q.Clone().Table("admins").Returning("id")
+				return q, c
+			},
+			`INSERT INTO "users" ("name") VALUES ($1)`,
+			`INSERT INTO "admins" ("name") VALUES ($1) RETURNING "id"`,
+		},
+		{
+			"Update",
+			func() (interface {
+				Build() (string, []interface{}, error)
+			}, interface {
+				Build() (string, []interface{}, error)
+			}) {
+				q := api.NewUpdateQueryBuilder(strategy).Table("users").Where("id", "=", 1).Update(map[string]interface{}{"name": "Jane"})
+				c := q.Clone().OrWhere("id", "=", 2)
+				return q, c
+			},
+			`UPDATE "users" SET "name" = $1 WHERE "id" = $2`,
+			`UPDATE "users" SET "name" = $1 WHERE "id" = $2 OR "id" = $3`,
+		},
+		{
+			"Delete",
+			func() (interface {
+				Build() (string, []interface{}, error)
+			}, interface {
+				Build() (string, []interface{}, error)
+			}) {
+				q := api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1)
+				c := q.Clone().Where("active", "=", false)
+				return q, c
+			},
+			`DELETE FROM "users" WHERE "id" = $1`,
+			`DELETE FROM "users" WHERE "id" = $1 AND "active" = $2`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			original, clone := tt.build()
+			for _, c := range []struct {
+				b interface {
+					Build() (string, []interface{}, error)
+				}
+				expected string
+			}{{original, tt.expectedOriginal}, {clone, tt.expectedClone}} {
+				query, _, err := c.b.Build()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if query != c.expected {
+					t.Errorf("expected '%s' but got '%s'", c.expected, query)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+)
+
+func TestConditionalApi(t *testing.T) {
+	active := func(q *api.SelectQueryBuilder) { q.Where("active", "=", true) }
+
+	tests := []struct {
+		name           string
+		build          func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"SelectWhen",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				name, age := "John", 0
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					When(name != "", func(q *api.SelectQueryBuilder) { q.Where("name", "=", name) }).
+					When(age > 0, func(q *api.SelectQueryBuilder) { q.Where("age", ">", age) }).
+					OrderBy("id", "asc").
+					Build()
+			},
+			"SELECT * FROM `users` WHERE `name` = ? ORDER BY `id` ASC",
+			[]interface{}{"John"},
+		},
+		{
+			"SelectUnlessAndTap",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					Tap(active).
+					Unless(false, func(q *api.SelectQueryBuilder) { q.Limit(10) }).
+					Unless(true, func(q *api.SelectQueryBuilder) { q.Offset(10) }).
+					Build()
+			},
+			"SELECT * FROM `users` WHERE `active` = ? LIMIT 10",
+			[]interface{}{true},
+		},
+		{
+			"SelectWhenElse",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					WhenElse(false,
+						func(q *api.SelectQueryBuilder) { q.OrderBy("name", "asc") },
+						func(q *api.SelectQueryBuilder) { q.OrderBy("created_at", "desc") },
+					).
+					Build()
+			},
+			"SELECT * FROM `users` ORDER BY `created_at` DESC",
+			nil,
+		},
+		{
+			"InsideWhereGroup",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					Where("active", "=", true).
+					WhereGroup(func(g *api.WhereSelectQueryBuilder) {
+						g.Where("role", "=", "admin").
+							When(true, func(q *api.SelectQueryBuilder) { q.OrWhere("role", "=", "owner") }).
+							When(false, func(q *api.SelectQueryBuilder) { q.OrWhere("role", "=", "guest") })
+					}).
+					Build()
+			},
+			"SELECT * FROM `users` WHERE `active` = ? AND (`role` = ? OR `role` = ?)",
+			[]interface{}{true, "admin", "owner"},
+		},
+		{
+			"Update",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").
+					Update(map[string]interface{}{"name": "Jane"}).
+					When(true, func(q *api.UpdateQueryBuilder) { q.Where("id", "=", 1) }).
+					Build()
+			},
+			"UPDATE `users` SET `name` = ? WHERE `id` = ?",
+			[]interface{}{"Jane", 1},
+		},
+		{
+			"Delete",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").
+					Where("id", "=", 1).
+					Unless(true, func(q *api.DeleteQueryBuilder) { q.OrWhere("id", "=", 2) }).
+					Build()
+			},
+			"DELETE FROM `users` WHERE `id` = ?",
+			[]interface{}{1},
+		},
+		{
+			"Insert",
+			func(strategy *mysql.MySQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").
+					WhenElse(true,
+						func(q *api.InsertQueryBuilder) { q.Insert(map[string]interface{}{"name": "John"}) },
+						func(q *api.InsertQueryBuilder) { q.Insert(map[string]interface{}{"name": "Anonymous"}) },
+					).
+					Build()
+			},
+			"INSERT INTO `users` (`name`) VALUES (?)",
+			[]interface{}{"John"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, values, err := tt.build(mysql.NewMySQLQueryBuilder())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package api_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+func TestCursorPaginateApiBuilder(t *testing.T) {
+	cursorFor := func(qb *api.SelectQueryBuilder, row map[string]interface{}) string {
+		c, err := qb.EncodeCursor(row)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"FirstPage",
+			func() (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					Where("active", "=", true).
+					OrderBy("id", "ASC").
+					CursorPaginate(10, "").
+					Build()
+			},
+			"SELECT * FROM `users` WHERE `active` = ? ORDER BY `id` ASC LIMIT 11",
+			[]interface{}{true},
+		},
+		{
+			"SingleColumn",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					OrderBy("id", "DESC")
+				return qb.CursorPaginate(10, cursorFor(qb, map[string]interface{}{"id": int64(42)})).Build()
+			},
+			"SELECT * FROM `users` WHERE `id` < ? ORDER BY `id` DESC LIMIT 11",
+			[]interface{}{int64(42)},
+		},
+		{
+			"RowComparison",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("users").
+					Where("active", "=", true).
+					OrderBy("created_at", "ASC").
+					OrderBy("users.id", "ASC")
+				cursor := cursorFor(qb, map[string]interface{}{"created_at": "2024-01-01", "id": int64(9007199254740993)})
+				return qb.CursorPaginate(20, cursor).Build()
+			},
+			`SELECT * FROM "users" WHERE "active" = $1 AND ("created_at", "users"."id") > ($2, $3) ORDER BY "created_at" ASC, "users"."id" ASC LIMIT 21`,
+			[]interface{}{true, "2024-01-01", int64(9007199254740993)},
+		},
+		{
+			"MixedDirections",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("posts").
+					OrderBy("score", "DESC").
+					OrderBy("id", "ASC")
+				cursor := cursorFor(qb, map[string]interface{}{"score": 1.5, "id": int64(7)})
+				return qb.CursorPaginate(5, cursor).Build()
+			},
+			"SELECT * FROM `posts` WHERE (`score` < ? OR (`score` = ? AND `id` > ?)) ORDER BY `score` DESC, `id` ASC LIMIT 6",
+			[]interface{}{1.5, 1.5, int64(7)},
+		},
+		{
+			"SQLServer",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					OrderBy("name", "ASC").
+					OrderBy("id", "ASC")
+				cursor := cursorFor(qb, map[string]interface{}{"name": "John", "id": int64(3)})
+				return qb.CursorPaginate(5, cursor).Build()
+			},
+			"SELECT TOP (6) * FROM [users] WHERE ([name] > @p1 OR ([name] = @p2 AND [id] > @p3)) ORDER BY [name] ASC, [id] ASC",
+			[]interface{}{"John", "John", int64(3)},
+		},
+		{
+			"OrWhere",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					Where("a", "=", 1).
+					OrWhere("b", "=", 2).
+					OrderBy("id", "ASC")
+				return qb.CursorPaginate(10, cursorFor(qb, map[string]interface{}{"id": int64(5)})).Build()
+			},
+			"SELECT * FROM `users` WHERE (`a` = ? OR `b` = ?) AND `id` > ? ORDER BY `id` ASC LIMIT 11",
+			[]interface{}{1, 2, int64(5)},
+		},
+		{
+			"OrWhereMixedDirections",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("posts").
+					Where("a", "=", 1).
+					OrWhere("b", "=", 2).
+					OrderBy("score", "DESC").
+					OrderBy("id", "ASC")
+				cursor := cursorFor(qb, map[string]interface{}{"score": 1.5, "id": int64(7)})
+				return qb.CursorPaginate(5, cursor).Build()
+			},
+			"SELECT * FROM `posts` WHERE (`a` = ? OR `b` = ?) AND (`score` < ? OR (`score` = ? AND `id` > ?)) ORDER BY `score` DESC, `id` ASC LIMIT 6",
+			[]interface{}{1, 2, 1.5, 1.5, int64(7)},
+		},
+		{
+			"CustomTiebreaker",
+			func() (string, []interface{}, error) {
+				qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					OrderBy("uuid", "ASC").
+					CursorTiebreaker("uuid")
+				return qb.CursorPaginate(5, cursorFor(qb, map[string]interface{}{"uuid": "abc"})).Build()
+			},
+			"SELECT * FROM `users` WHERE `uuid` > ? ORDER BY `uuid` ASC LIMIT 6",
+			[]interface{}{"abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestCursorPaginateApiBuilderErrors(t *testing.T) {
+	other := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).OrderBy("name", "ASC").OrderBy("id", "ASC")
+	otherCursor, err := other.EncodeCursor(map[string]interface{}{"name": "a", "id": 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		qb       *api.SelectQueryBuilder
+		expected error
+	}{
+		{
+			"NoOrder",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").CursorPaginate(10, ""),
+			api.ErrCursorNoOrder,
+		},
+		{
+			"NoTiebreaker",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").OrderBy("created_at", "DESC").CursorPaginate(10, ""),
+			api.ErrCursorNoTiebreaker,
+		},
+		{
+			"RawOrder",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").OrderByRaw("RAND()").CursorPaginate(10, ""),
+			api.ErrCursorRawOrder,
+		},
+		{
+			"InvalidCursor",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").OrderBy("id", "ASC").CursorPaginate(10, "!!"),
+			api.ErrCursorInvalid,
+		},
+		{
+			"OrderMismatch",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").OrderBy("id", "ASC").CursorPaginate(10, otherCursor),
+			api.ErrCursorOrderMismatch,
+		},
+		{
+			"InvalidPerPage",
+			api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").OrderBy("id", "ASC").CursorPaginate(0, ""),
+			api.ErrInvalidPerPage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.qb.Build(); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestCursorPage(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	rec.AddRows([]string{"id", "name"},
+		[]driver.Value{int64(1), "a"},
+		[]driver.Value{int64(2), "b"},
+		[]driver.Value{int64(3), "c"},
+	)
+
+	qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		SetQueryer(db).
+		Table("users").
+		OrderBy("id", "ASC").
+		CursorPaginate(2, "")
+
+	page, err := qb.CursorPage(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 2 || page.NextCursor == "" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	// the next page continues after the last returned row
+	rec.AddRows([]string{"id", "name"}, []driver.Value{int64(3), "c"})
+	page, err = qb.CursorPaginate(2, page.NextCursor).CursorPage(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Items) != 1 || page.NextCursor != "" {
+		t.Errorf("unexpected page %+v", page)
+	}
+
+	queries := rec.Queries()
+	expected := []string{
+		"SELECT * FROM `users` ORDER BY `id` ASC LIMIT 3",
+		"SELECT * FROM `users` WHERE `id` > ? ORDER BY `id` ASC LIMIT 3",
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Errorf("expected queries %v but got %v", expected, queries)
+	}
+	if args := rec.Calls()[1].Args; !reflect.DeepEqual(args, []interface{}{int64(2)}) {
+		t.Errorf("unexpected args %v", args)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/faciam-dev/goquent-query-builder/api"
 	"github.com/faciam-dev/goquent-query-builder/database/mysql"
 	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
 )
 
 func TestSelectDebugApiRawSqlTest(t *testing.T) {
@@ -246,6 +247,37 @@ func TestSelectDebugApiRawSqlPostgreSQLConsistency(t *testing.T) {
 	}
 }
 
+func TestSelectDebugApiRawSqlSQLServer(t *testing.T) {
+	t.Parallel()
+
+	builder := api.NewSelectQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+		Table("users").
+		Where("name", "=", "John").
+		Where("age", ">", 18).
+		OrderBy("id", "asc").
+		Limit(10).
+		Offset(20)
+
+	expectedQuery := "SELECT * FROM [users] WHERE [name] = @p1 AND [age] > @p2 ORDER BY [id] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"
+	expectedRawSQL := "SELECT * FROM [users] WHERE [name] = 'John' AND [age] > 18 ORDER BY [id] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY"
+
+	query, _, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build returned error: %v", err)
+	}
+	if query != expectedQuery {
+		t.Fatalf("expected build query %q but got %q", expectedQuery, query)
+	}
+
+	rawQuery, err := builder.RawSql()
+	if err != nil {
+		t.Fatalf("RawSql returned error: %v", err)
+	}
+	if rawQuery != expectedRawSQL {
+		t.Fatalf("expected raw SQL %q but got %q", expectedRawSQL, rawQuery)
+	}
+}
+
 func TestInsertDebugApiRawSqlTest(t *testing.T) {
 	tests := []struct {
 		name          string
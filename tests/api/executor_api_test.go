@@ -0,0 +1,347 @@
+package api_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/executor"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+func TestExecutorApiSelect(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		rows          [][]driver.Value
+		columns       []string
+		run           func(qb *api.SelectQueryBuilder) (interface{}, error)
+		expected      interface{}
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			"Get",
+			[][]driver.Value{{int64(1), []byte("John")}, {int64(2), "Jane"}},
+			[]string{"id", "name"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Get(ctx)
+			},
+			[]map[string]interface{}{{"id": int64(1), "name": "John"}, {"id": int64(2), "name": "Jane"}},
+			"SELECT * FROM `users` WHERE `age` > ?",
+			[]interface{}{int64(18)},
+		},
+		{
+			"First",
+			[][]driver.Value{{int64(1), "John"}},
+			[]string{"id", "name"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.First(ctx)
+			},
+			map[string]interface{}{"id": int64(1), "name": "John"},
+			"SELECT * FROM `users` WHERE `age` > ? LIMIT 1",
+			[]interface{}{int64(18)},
+		},
+		{
+			"Value",
+			[][]driver.Value{{"john@example.com"}},
+			[]string{"email"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Value(ctx, "email")
+			},
+			"john@example.com",
+			"SELECT `email` FROM `users` WHERE `age` > ? LIMIT 1",
+			[]interface{}{int64(18)},
+		},
+		{
+			"Pluck",
+			[][]driver.Value{{"John"}, {"Jane"}},
+			[]string{"name"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Pluck(ctx, "name")
+			},
+			[]interface{}{"John", "Jane"},
+			"SELECT `name` FROM `users` WHERE `age` > ?",
+			[]interface{}{int64(18)},
+		},
+		{
+			"Exists",
+			[][]driver.Value{{int64(1)}},
+			[]string{"exists"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Exists(ctx)
+			},
+			true,
+			"SELECT CASE WHEN EXISTS (SELECT * FROM `users` WHERE `age` > ?) THEN 1 ELSE 0 END",
+			[]interface{}{int64(18)},
+		},
+		{
+			"Count",
+			[][]driver.Value{{[]byte("42")}},
+			[]string{"count"},
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Count(ctx)
+			},
+			int64(42),
+			"SELECT COUNT(*) FROM `users` WHERE `age` > ?",
+			[]interface{}{int64(18)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := fakedb.Open()
+			defer db.Close()
+			rec.AddRows(tt.columns, tt.rows...)
+
+			qb := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+				SetQueryer(db).
+				Table("users").
+				Where("age", ">", 18)
+
+			got, err := tt.run(qb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+
+			calls := rec.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("expected 1 call but got %d", len(calls))
+			}
+			if calls[0].Query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, calls[0].Query)
+			}
+			if !reflect.DeepEqual(calls[0].Args, tt.expectedArgs) {
+				t.Errorf("expected args %v but got %v", tt.expectedArgs, calls[0].Args)
+			}
+
+			// terminal methods must not leave their selection on the builder
+			query, _, err := qb.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != "SELECT * FROM `users` WHERE `age` > ?" {
+				t.Errorf("builder was modified: '%s'", query)
+			}
+		})
+	}
+}
+
+// TestExecutorApiExistsCount checks that Exists and Count drop the ORDER BY
+// and lock, which are not allowed in a subquery.
+func TestExecutorApiExistsCount(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		strategy      interfaces.QueryBuilderStrategy
+		run           func(qb *api.SelectQueryBuilder) (interface{}, error)
+		expected      interface{}
+		expectedQuery string
+	}{
+		{
+			"ExistsMySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Exists(ctx)
+			},
+			true,
+			"SELECT CASE WHEN EXISTS (SELECT * FROM `users` WHERE `age` > ?) THEN 1 ELSE 0 END",
+		},
+		{
+			"ExistsSQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Exists(ctx)
+			},
+			true,
+			"SELECT CASE WHEN EXISTS (SELECT * FROM [users] WHERE [age] > @p1) THEN 1 ELSE 0 END",
+		},
+		{
+			"ExistsSQLServerLimit",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Limit(5).Exists(ctx)
+			},
+			true,
+			"SELECT CASE WHEN EXISTS (SELECT TOP (5) * FROM [users] WHERE [age] > @p1 ORDER BY [name] ASC) THEN 1 ELSE 0 END",
+		},
+		{
+			"CountMySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Count(ctx)
+			},
+			int64(1),
+			"SELECT COUNT(*) FROM `users` WHERE `age` > ?",
+		},
+		{
+			"CountSQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Count(ctx)
+			},
+			int64(1),
+			"SELECT COUNT(*) FROM [users] WHERE [age] > @p1",
+		},
+		{
+			"CountSQLServerDistinct",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(qb *api.SelectQueryBuilder) (interface{}, error) {
+				return qb.Distinct("name").Count(ctx)
+			},
+			int64(1),
+			"SELECT COUNT(*) FROM (SELECT DISTINCT [name] FROM [users] WHERE [age] > @p1) AS aggregate_table",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := fakedb.Open()
+			defer db.Close()
+			rec.AddRows([]string{"result"}, []driver.Value{int64(1)})
+
+			qb := api.NewSelectQueryBuilder(tt.strategy).
+				SetQueryer(db).
+				Table("users").
+				Where("age", ">", 18).
+				OrderBy("name", "ASC").
+				LockForUpdate()
+
+			got, err := tt.run(qb)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+
+			calls := rec.Calls()
+			if len(calls) != 1 {
+				t.Fatalf("expected 1 call but got %d", len(calls))
+			}
+			if calls[0].Query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, calls[0].Query)
+			}
+		})
+	}
+}
+
+func TestExecutorApiFirstNoRows(t *testing.T) {
+	db, _ := fakedb.Open()
+	defer db.Close()
+
+	_, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		SetQueryer(db).
+		Table("users").
+		First(context.Background())
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows but got %v", err)
+	}
+}
+
+func TestExecutorApiNoQueryer(t *testing.T) {
+	_, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		Table("users").
+		Get(context.Background())
+	if !errors.Is(err, executor.ErrNoQueryer) {
+		t.Errorf("expected executor.ErrNoQueryer but got %v", err)
+	}
+}
+
+func TestExecutorApiExec(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name          string
+		run           func(q executor.Queryer) (executor.Result, error)
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		{
+			"Insert",
+			func(q executor.Queryer) (executor.Result, error) {
+				return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					SetQueryer(q).
+					Table("users").
+					Insert(map[string]interface{}{"name": "John"}).
+					Exec(ctx)
+			},
+			"INSERT INTO `users` (`name`) VALUES (?)",
+			[]interface{}{"John"},
+		},
+		{
+			"Update",
+			func(q executor.Queryer) (executor.Result, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					SetQueryer(q).
+					Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "Jane"}).
+					Exec(ctx)
+			},
+			`UPDATE "users" SET "name" = $1 WHERE "id" = $2`,
+			[]interface{}{"Jane", int64(1)},
+		},
+		{
+			"Delete",
+			func(q executor.Queryer) (executor.Result, error) {
+				return api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					SetQueryer(q).
+					Table("users").
+					Where("id", "=", 1).
+					Exec(ctx)
+			},
+			"DELETE FROM `users` WHERE `id` = ?",
+			[]interface{}{int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := fakedb.Open()
+			defer db.Close()
+			rec.SetResult(7, 1)
+
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			res, err := tt.run(tx)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if err := tx.Commit(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if res != (executor.Result{RowsAffected: 1, LastInsertID: 7}) {
+				t.Errorf("unexpected result %+v", res)
+			}
+
+			calls := rec.Calls()
+			if len(calls) != 3 || calls[0].Query != "BEGIN" || calls[2].Query != "COMMIT" {
+				t.Fatalf("unexpected calls %v", calls)
+			}
+			if calls[1].Query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, calls[1].Query)
+			}
+			if !reflect.DeepEqual(calls[1].Args, tt.expectedArgs) {
+				t.Errorf("expected args %v but got %v", tt.expectedArgs, calls[1].Args)
+			}
+		})
+	}
+}
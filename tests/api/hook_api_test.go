@@ -0,0 +1,243 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/hook"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+func tenantHook(e *hook.Event) error {
+	if e.Kind == hook.KindInsert {
+		return nil
+	}
+	return e.Where("tenant_id", "=", 7)
+}
+
+func tagHook(e *hook.Event) error {
+	e.SQL = "/* app:" + e.Kind + " */ " + e.SQL
+	return nil
+}
+
+func TestHookApiBuild(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Select",
+			func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Where("active", "=", true).Build()
+			},
+			`/* app:select */ SELECT * FROM "users" WHERE "active" = $1 AND ("tenant_id" = $2)`,
+			[]interface{}{true, 7},
+		},
+		{
+			"SelectWithOr",
+			func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Where("name", "=", "John").OrWhere("age", ">", 30).Build()
+			},
+			`/* app:select */ SELECT * FROM "users" WHERE ("name" = $1 OR "age" > $2) AND ("tenant_id" = $3)`,
+			[]interface{}{"John", 30, 7},
+		},
+		{
+			"Insert",
+			func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").Insert(map[string]interface{}{"name": "John"}).Build()
+			},
+			`/* app:insert */ INSERT INTO "users" ("name") VALUES ($1)`,
+			[]interface{}{"John"},
+		},
+		{
+			"Update",
+			func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").Where("id", "=", 1).Update(map[string]interface{}{"name": "Jane"}).Build()
+			},
+			`/* app:update */ UPDATE "users" SET "name" = $1 WHERE "id" = $2 AND ("tenant_id" = $3)`,
+			[]interface{}{"Jane", 1, 7},
+		},
+		{
+			"Delete",
+			func(strategy *postgres.PostgreSQLQueryBuilder) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).Build()
+			},
+			`/* app:delete */ DELETE FROM "users" WHERE "id" = $1 AND ("tenant_id" = $2)`,
+			[]interface{}{1, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy := postgres.NewPostgreSQLQueryBuilder()
+			strategy.Use(hook.Funcs{Before: tenantHook, After: tagHook})
+
+			// building twice must not add the condition twice
+			for i := 0; i < 2; i++ {
+				query, values, err := tt.build(strategy)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if query != tt.expectedQuery {
+					t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+				}
+				if !reflect.DeepEqual(values, tt.expectedValues) {
+					t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+				}
+			}
+		})
+	}
+}
+
+func TestHookApiBuildErrors(t *testing.T) {
+	errRejected := errors.New("rejected")
+
+	tests := []struct {
+		name     string
+		hook     hook.Hook
+		build    func(strategy *mysql.MySQLQueryBuilder) error
+		expected error
+	}{
+		{
+			"AmbiguousWhere",
+			hook.Funcs{Before: tenantHook},
+			func(strategy *mysql.MySQLQueryBuilder) error {
+				_, _, err := api.NewSelectQueryBuilder(strategy).Table("users").
+					WhereGroup(func(q *api.WhereSelectQueryBuilder) {
+						q.Where("a", "=", 1)
+					}).
+					OrWhereGroup(func(q *api.WhereSelectQueryBuilder) {
+						q.Where("b", "=", 2)
+					}).
+					Build()
+				return err
+			},
+			hook.ErrAmbiguousWhere,
+		},
+		{
+			"WhereOnInsert",
+			hook.Funcs{Before: func(e *hook.Event) error { return e.Where("tenant_id", "=", 7) }},
+			func(strategy *mysql.MySQLQueryBuilder) error {
+				_, _, err := api.NewInsertQueryBuilder(strategy).Table("users").Insert(map[string]interface{}{"name": "John"}).Build()
+				return err
+			},
+			hook.ErrWhereNotSupported,
+		},
+		{
+			"WhereOnUnion",
+			hook.Funcs{Before: tenantHook},
+			func(strategy *mysql.MySQLQueryBuilder) error {
+				_, _, err := api.NewSelectQueryBuilder(strategy).Table("orders").Select("id").
+					Union(api.NewSelectQueryBuilder(strategy).Table("orders").Select("id").Where("x", "=", 1)).
+					Build()
+				return err
+			},
+			hook.ErrWhereUnion,
+		},
+		{
+			"RejectedAfterBuild",
+			hook.Funcs{After: func(e *hook.Event) error { return errRejected }},
+			func(strategy *mysql.MySQLQueryBuilder) error {
+				_, _, err := api.NewDeleteQueryBuilder(strategy).Table("users").Build()
+				return err
+			},
+			errRejected,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			strategy := mysql.NewMySQLQueryBuilder()
+			strategy.Use(tt.hook)
+
+			if err := tt.build(strategy); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestHookApiExecute(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+	ctx := context.Background()
+
+	var trace []string
+	around := func(name string) hook.Hook {
+		return hook.Funcs{Around: func(ctx context.Context, e *hook.Event, next func(ctx context.Context) error) error {
+			trace = append(trace, name+" before "+e.Kind)
+			err := next(ctx)
+			trace = append(trace, name+" after "+e.Kind)
+			return err
+		}}
+	}
+
+	var timed []string
+	strategy := mysql.NewMySQLQueryBuilder()
+	strategy.Use(
+		around("outer"),
+		around("inner"),
+		hook.Funcs{Around: func(ctx context.Context, e *hook.Event, next func(ctx context.Context) error) error {
+			e.SQL += " -- rewritten"
+			return next(ctx)
+		}},
+		hook.Timing(func(e *hook.Event, d time.Duration, err error) {
+			timed = append(timed, e.SQL)
+		}),
+	)
+
+	if _, err := api.NewSelectQueryBuilder(strategy).SetQueryer(db).Table("users").Get(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := api.NewUpdateQueryBuilder(strategy).SetQueryer(db).Table("users").Update(map[string]interface{}{"name": "Jane"}).Exec(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedTrace := []string{
+		"outer before select", "inner before select", "inner after select", "outer after select",
+		"outer before update", "inner before update", "inner after update", "outer after update",
+	}
+	if !reflect.DeepEqual(trace, expectedTrace) {
+		t.Errorf("expected %q but got %q", expectedTrace, trace)
+	}
+
+	expectedQueries := []string{"SELECT * FROM `users` -- rewritten", "UPDATE `users` SET `name` = ? -- rewritten"}
+	if queries := rec.Queries(); !reflect.DeepEqual(queries, expectedQueries) {
+		t.Errorf("expected %q but got %q", expectedQueries, queries)
+	}
+	if !reflect.DeepEqual(timed, expectedQueries) {
+		t.Errorf("expected %q to be timed but got %q", expectedQueries, timed)
+	}
+}
+
+func TestHookApiSlowQuery(t *testing.T) {
+	db, _ := fakedb.Open()
+	defer db.Close()
+
+	var out bytes.Buffer
+	strategy := mysql.NewMySQLQueryBuilder()
+	strategy.Use(hook.SlowQuery(0, log.New(&out, "", 0)), hook.SlowQuery(time.Hour, log.New(&out, "", 0)))
+
+	if _, err := api.NewDeleteQueryBuilder(strategy).SetQueryer(db).Table("users").Where("id", "=", 1).Exec(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if lines := strings.Count(out.String(), "\n"); lines != 1 || !strings.Contains(out.String(), "DELETE FROM `users` WHERE `id` = ? [1]") {
+		t.Errorf("expected one slow query log line but got %q", out.String())
+	}
+}
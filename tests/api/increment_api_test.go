@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestIncrementApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Increment",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("posts").
+					Where("id", "=", 5).
+					Increment("views", 1).
+					Build()
+			},
+			"UPDATE `posts` SET `views` = `views` + ? WHERE `id` = ?",
+			[]interface{}{1, 5},
+		},
+		{
+			"DecrementWithExtra",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("products").
+					Where("id", "=", 3).
+					Decrement("stock", 2, map[string]interface{}{"status": "reserved"}).
+					Build()
+			},
+			`UPDATE "products" SET "status" = $1, "stock" = "stock" - $2 WHERE "id" = $3`,
+			[]interface{}{"reserved", 2, 3},
+		},
+		{
+			"IncrementEach",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").
+					Where("id", "=", 1).
+					IncrementEach(map[string]interface{}{"logins": 1, "points": 2.5}).
+					Build()
+			},
+			`UPDATE [users] SET [logins] = [logins] + @p1, [points] = [points] + @p2 WHERE [id] = @p3`,
+			[]interface{}{1, 2.5, 1},
+		},
+		{
+			"IncrementThenUpdate",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("posts").
+					Where("id", "=", 5).
+					Increment("views", 1).
+					Update(map[string]interface{}{"title": "x"}).
+					Build()
+			},
+			`UPDATE "posts" SET "title" = $1, "views" = "views" + $2 WHERE "id" = $3`,
+			[]interface{}{"x", 1, 5},
+		},
+		{
+			"JoinOrderBy",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Where("profiles.active", "=", true).
+					OrderBy("users.id", "ASC").
+					Increment("users.score", 10).
+					Build()
+			},
+			"UPDATE `users` INNER JOIN `profiles` ON `users`.`id` = `profiles`.`user_id` SET `users`.`score` = `users`.`score` + ? WHERE `profiles`.`active` = ? ORDER BY `users`.`id` ASC",
+			[]interface{}{10, true},
+		},
+		{
+			"JSONPathMySQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					Where("id", "=", 1).
+					Increment("stats->visits", 1).
+					Build()
+			},
+			"UPDATE `users` SET `stats` = JSON_SET(`stats`, '$.visits', JSON_EXTRACT(`stats`, '$.visits') + ?) WHERE `id` = ?",
+			[]interface{}{1, 1},
+		},
+		{
+			"JSONPathPostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Where("id", "=", 1).
+					Decrement("stats->counters->visits", 1).
+					Build()
+			},
+			`UPDATE "users" SET "stats" = jsonb_set("stats", '{counters,visits}', to_jsonb(("stats" #>> '{counters,visits}')::numeric - $1)) WHERE "id" = $2`,
+			[]interface{}{1, 1},
+		},
+		{
+			"JSONPathSQLite",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlite.NewSQLiteQueryBuilder()).Table("users").
+					Where("id", "=", 1).
+					Increment("stats->visits", 1).
+					Build()
+			},
+			`UPDATE "users" SET "stats" = json_set("stats", '$.visits', json_extract("stats", '$.visits') + ?) WHERE "id" = ?`,
+			[]interface{}{1, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestIncrementApiErrors(t *testing.T) {
+	_, _, err := api.NewUpdateQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").
+		Increment("stats->visits", 1).
+		Build()
+	if !errors.Is(err, api.ErrJSONArithmeticNotSupported) {
+		t.Errorf("expected ErrJSONArithmeticNotSupported but got %v", err)
+	}
+
+	_, _, err = api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+		Increment("visits", "1").
+		Build()
+	if !errors.Is(err, api.ErrNonNumericAmount) {
+		t.Errorf("expected ErrNonNumericAmount but got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestLimitApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"UpdateMySQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("jobs").
+					Where("status", "=", "pending").
+					OrderBy("id", "ASC").
+					Limit(100).
+					Update(map[string]interface{}{"status": "running"}).
+					Build()
+			},
+			"UPDATE `jobs` SET `status` = ? WHERE `status` = ? ORDER BY `id` ASC LIMIT 100",
+			[]interface{}{"running", "pending"},
+		},
+		{
+			"DeleteMySQL",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("logs").
+					Where("created_at", "<", "2024-01-01").
+					OrderBy("id", "ASC").
+					Limit(1000).
+					Build()
+			},
+			"DELETE FROM `logs` WHERE `created_at` < ? ORDER BY `id` ASC LIMIT 1000",
+			[]interface{}{"2024-01-01"},
+		},
+		{
+			"DeletePostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("logs").
+					Where("created_at", "<", "2024-01-01").
+					OrderBy("id", "ASC").
+					Limit(1000).
+					Returning("id").
+					Build()
+			},
+			`DELETE FROM "logs" WHERE "ctid" IN (SELECT "ctid" FROM "logs" WHERE "created_at" < $1 ORDER BY "id" ASC LIMIT 1000) RETURNING "id"`,
+			[]interface{}{"2024-01-01"},
+		},
+		{
+			"UpdatePostgreSQLLimitKey",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("jobs").
+					Where("status", "=", "pending").
+					Limit(10).
+					LimitKey("id").
+					Update(map[string]interface{}{"status": "running"}).
+					Build()
+			},
+			`UPDATE "jobs" SET "status" = $1 WHERE "id" IN (SELECT "id" FROM "jobs" WHERE "status" = $2 LIMIT 10)`,
+			[]interface{}{"running", "pending"},
+		},
+		{
+			"DeleteSQLite",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(sqlite.NewSQLiteQueryBuilder()).Table("logs").
+					Where("level", "=", "debug").
+					Limit(500).
+					Build()
+			},
+			`DELETE FROM "logs" WHERE "rowid" IN (SELECT "rowid" FROM "logs" WHERE "level" = ? LIMIT 500)`,
+			[]interface{}{"debug"},
+		},
+		{
+			"UpdateSQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("jobs").
+					Where("status", "=", "pending").
+					Limit(10).
+					Update(map[string]interface{}{"status": "running"}).
+					Build()
+			},
+			`UPDATE TOP (10) [jobs] SET [status] = @p1 WHERE [status] = @p2`,
+			[]interface{}{"running", "pending"},
+		},
+		{
+			"DeleteSQLServerOrdered",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("logs").
+					Where("level", "=", "debug").
+					OrderBy("id", "ASC").
+					Limit(500).
+					LimitKey("id").
+					Build()
+			},
+			`DELETE FROM [logs] WHERE [id] IN (SELECT TOP (500) [id] FROM [logs] WHERE [level] = @p1 ORDER BY [id] ASC)`,
+			[]interface{}{"debug"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestLimitApiNotSupported(t *testing.T) {
+	_, _, err := api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+		Join("profiles", "users.id", "=", "profiles.user_id").
+		Limit(10).
+		Build()
+	if !errors.Is(err, api.ErrLimitNotSupported) {
+		t.Errorf("expected ErrLimitNotSupported but got %v", err)
+	}
+
+	_, _, err = api.NewDeleteQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("logs").
+		OrderBy("id", "ASC").
+		Limit(10).
+		Build()
+	if !errors.Is(err, api.ErrLimitNotSupported) {
+		t.Errorf("expected ErrLimitNotSupported but got %v", err)
+	}
+}
@@ -0,0 +1,207 @@
+package api_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+func TestPaginateSQL(t *testing.T) {
+	tests := []struct {
+		name                string
+		qb                  func() *api.SelectQueryBuilder
+		page                int64
+		perPage             int64
+		expectedQuery       string
+		expectedValues      []interface{}
+		expectedCountQuery  string
+		expectedCountValues []interface{}
+	}{
+		{
+			"Simple",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					Select("id", "name").
+					Where("age", ">", 18).
+					OrderBy("name", "ASC").
+					Limit(5)
+			},
+			3, 10,
+			"SELECT `id`, `name` FROM `users` WHERE `age` > ? ORDER BY `name` ASC LIMIT 10 OFFSET 20",
+			[]interface{}{18},
+			"SELECT COUNT(*) FROM `users` WHERE `age` > ?",
+			[]interface{}{18},
+		},
+		{
+			"GroupBy",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("orders").
+					Select("user_id").
+					Where("status", "=", "paid").
+					GroupBy("user_id").
+					Having("user_id", ">", 10)
+			},
+			1, 20,
+			`SELECT "user_id" FROM "orders" WHERE "status" = $1 GROUP BY "user_id" HAVING "user_id" > $2 LIMIT 20`,
+			[]interface{}{"paid", 10},
+			`SELECT COUNT(*) FROM (SELECT "user_id" FROM "orders" WHERE "status" = $1 GROUP BY "user_id" HAVING "user_id" > $2) AS aggregate_table`,
+			[]interface{}{"paid", 10},
+		},
+		{
+			"Distinct",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("orders").
+					Distinct("user_id")
+			},
+			2, 5,
+			"SELECT DISTINCT `user_id` FROM `orders` LIMIT 5 OFFSET 5",
+			nil,
+			"SELECT COUNT(*) FROM (SELECT DISTINCT `user_id` FROM `orders`) AS aggregate_table",
+			nil,
+		},
+		{
+			"Union",
+			func() *api.SelectQueryBuilder {
+				admins := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("admins").Select("name")
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					Select("name").
+					Union(admins)
+			},
+			1, 10,
+			"SELECT `name` FROM `admins` UNION SELECT `name` FROM `users` LIMIT 10",
+			nil,
+			"SELECT COUNT(*) FROM (SELECT `name` FROM `admins` UNION SELECT `name` FROM `users`) AS aggregate_table",
+			nil,
+		},
+		{
+			"SQLServer",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					Where("age", ">", 18).
+					OrderBy("id", "ASC")
+			},
+			2, 10,
+			"SELECT * FROM [users] WHERE [age] > @p1 ORDER BY [id] ASC OFFSET 10 ROWS FETCH NEXT 10 ROWS ONLY",
+			[]interface{}{18},
+			"SELECT COUNT(*) FROM [users] WHERE [age] > @p1",
+			[]interface{}{18},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qb := tt.qb()
+			before, _, err := qb.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			pq, err := qb.PaginateSQL(tt.page, tt.perPage)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if pq.Query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, pq.Query)
+			}
+			if !reflect.DeepEqual(pq.Values, tt.expectedValues) && len(pq.Values)+len(tt.expectedValues) > 0 {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, pq.Values)
+			}
+			if pq.CountQuery != tt.expectedCountQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedCountQuery, pq.CountQuery)
+			}
+			if !reflect.DeepEqual(pq.CountValues, tt.expectedCountValues) && len(pq.CountValues)+len(tt.expectedCountValues) > 0 {
+				t.Errorf("expected values %v but got %v", tt.expectedCountValues, pq.CountValues)
+			}
+
+			after, _, err := qb.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if before != after {
+				t.Errorf("builder was modified: '%s' became '%s'", before, after)
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	rec.AddRows([]string{"COUNT(*)"}, []driver.Value{int64(25)})
+	rec.AddRows([]string{"id"}, []driver.Value{int64(11)}, []driver.Value{int64(12)})
+
+	p, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		Table("users").
+		Select("id").
+		Paginate(context.Background(), db, 2, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := &api.Pagination{
+		Items:       []map[string]interface{}{{"id": int64(11)}, {"id": int64(12)}},
+		Total:       25,
+		PerPage:     10,
+		CurrentPage: 2,
+		LastPage:    3,
+		HasNextPage: true,
+		HasPrevPage: true,
+	}
+	if !reflect.DeepEqual(p, expected) {
+		t.Errorf("expected %+v but got %+v", expected, p)
+	}
+
+	queries := rec.Queries()
+	expectedQueries := []string{
+		"SELECT COUNT(*) FROM `users`",
+		"SELECT `id` FROM `users` LIMIT 10 OFFSET 10",
+	}
+	if !reflect.DeepEqual(queries, expectedQueries) {
+		t.Errorf("expected queries %v but got %v", expectedQueries, queries)
+	}
+}
+
+func TestSimplePaginate(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	rec.AddRows([]string{"id"}, []driver.Value{int64(1)}, []driver.Value{int64(2)}, []driver.Value{int64(3)})
+
+	p, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		SetQueryer(db).
+		Table("users").
+		Select("id").
+		SimplePaginate(context.Background(), nil, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(p.Items) != 2 || !p.HasNextPage || p.HasPrevPage || p.Total != 0 {
+		t.Errorf("unexpected pagination %+v", p)
+	}
+
+	if q := rec.Queries(); len(q) != 1 || q[0] != "SELECT `id` FROM `users` LIMIT 3" {
+		t.Errorf("unexpected queries %v", q)
+	}
+
+	if _, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").PaginateSQL(1, 0); !errors.Is(err, api.ErrInvalidPerPage) {
+		t.Errorf("expected ErrInvalidPerPage but got %v", err)
+	}
+}
@@ -0,0 +1,135 @@
+package api_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestRawApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Update",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("posts").
+					Where("id", "=", 5).
+					Update(map[string]interface{}{"title": "Hello", "views": api.Raw("`views` + ?", 1), "updated_at": api.Raw("NOW()")}).
+					Build()
+			},
+			"UPDATE `posts` SET `title` = ?, `updated_at` = NOW(), `views` = `views` + ? WHERE `id` = ?",
+			[]interface{}{"Hello", 1, 5},
+		},
+		{
+			"UpdateJSONPath",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"options->language": api.Raw("to_jsonb(?::text)", "en")}).
+					Build()
+			},
+			`UPDATE "users" SET "options" = jsonb_set("options", '{language}', to_jsonb($1::text)) WHERE "id" = $2`,
+			[]interface{}{"en", 1},
+		},
+		{
+			"Where",
+			func() (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("sessions").
+					Where("user_id", "=", 1).
+					Where("expires_at", "<", api.Raw("now() - ? * INTERVAL '1 day'", 7)).
+					Where("id", "!=", 3).
+					Build()
+			},
+			`SELECT * FROM "sessions" WHERE "user_id" = $1 AND "expires_at" < now() - $2 * INTERVAL '1 day' AND "id" != $3`,
+			[]interface{}{1, 7, 3},
+		},
+		{
+			"Insert",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Insert(map[string]interface{}{"created_at": api.Raw("now()"), "email": api.Raw("lower(?)", "John@Example.com"), "name": "John"}).
+					Build()
+			},
+			`INSERT INTO "users" ("created_at", "email", "name") VALUES (now(), lower($1), $2)`,
+			[]interface{}{"John@Example.com", "John"},
+		},
+		{
+			"InsertBatch",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").
+					InsertBatch([]map[string]interface{}{
+						{"email": api.Raw("LOWER(?)", "A@example.com"), "name": "A"},
+						{"email": api.Raw("LOWER(?)", "B@example.com"), "name": "B"},
+					}).
+					Build()
+			},
+			`INSERT INTO [users] ([email], [name]) VALUES (LOWER(@p1), @p2), (LOWER(@p3), @p4)`,
+			[]interface{}{"A@example.com", "A", "B@example.com", "B"},
+		},
+		{
+			"UpsertSet",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Upsert([]map[string]interface{}{{"email": "john@example.com", "name": "John"}}, []string{"email"}, []string{"name"}).
+					UpsertSet(map[string]interface{}{"visits": api.Raw(`"users"."visits" + ?`, 1)}).
+					Build()
+			},
+			`INSERT INTO "users" ("email", "name") VALUES ($1, $2) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "visits" = "users"."visits" + $3`,
+			[]interface{}{"john@example.com", "John", 1},
+		},
+		{
+			"UpsertSetMySQL",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					Upsert([]map[string]interface{}{{"email": "john@example.com"}}, []string{"email"}, nil).
+					UpsertSet(map[string]interface{}{"visits": api.Raw("`visits` + ?", 1)}).
+					Build()
+			},
+			"INSERT INTO `users` (`email`) VALUES (?) ON DUPLICATE KEY UPDATE `visits` = `visits` + ?",
+			[]interface{}{"john@example.com", 1},
+		},
+		{
+			"UpsertSetSQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").
+					Upsert([]map[string]interface{}{{"email": "john@example.com", "name": "John"}}, []string{"email"}, []string{"name"}).
+					UpsertSet(map[string]interface{}{"visits": api.Raw("[target].[visits] + ?", 1)}).
+					Build()
+			},
+			`MERGE INTO [users] AS [target] USING (VALUES (@p1, @p2)) AS [source] ([email], [name]) ON [target].[email] = [source].[email] WHEN MATCHED THEN UPDATE SET [target].[name] = [source].[name], [target].[visits] = [target].[visits] + @p3 WHEN NOT MATCHED THEN INSERT ([email], [name]) VALUES ([source].[email], [source].[name]);`,
+			[]interface{}{"john@example.com", "John", 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestRawApiBindingMismatch(t *testing.T) {
+	_, _, err := api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("posts").
+		Update(map[string]interface{}{"views": api.Raw("`views` + ? + ?", 1)}).
+		Build()
+	if err == nil {
+		t.Error("expected an error for a binding count mismatch")
+	}
+}
@@ -0,0 +1,203 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestReturningApiBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Insert_PostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("users").
+					Insert(map[string]interface{}{"name": "John"}).
+					Returning("id", "created_at").
+					Build()
+			},
+			`INSERT INTO "users" ("name") VALUES ($1) RETURNING "id", "created_at"`,
+			[]interface{}{"John"},
+		},
+		{
+			"InsertOrIgnore_PostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("users").
+					InsertOrIgnore([]map[string]interface{}{{"name": "John"}}).
+					Returning("id").
+					Build()
+			},
+			`INSERT INTO "users" ("name") VALUES ($1) ON CONFLICT DO NOTHING RETURNING "id"`,
+			[]interface{}{"John"},
+		},
+		{
+			"Upsert_SQLite",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(sqlite.NewSQLiteQueryBuilder()).
+					Table("users").
+					Upsert([]map[string]interface{}{{"email": "a@example.com", "name": "John"}}, []string{"email"}, []string{"name"}).
+					Returning("*").
+					Build()
+			},
+			`INSERT INTO "users" ("email", "name") VALUES (?, ?) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name" RETURNING *`,
+			[]interface{}{"a@example.com", "John"},
+		},
+		{
+			"InsertBatch_SQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					InsertBatch([]map[string]interface{}{{"name": "John"}, {"name": "Jane"}}).
+					Returning("id").
+					Build()
+			},
+			"INSERT INTO [users] ([name]) OUTPUT INSERTED.[id] VALUES (@p1), (@p2)",
+			[]interface{}{"John", "Jane"},
+		},
+		{
+			"Upsert_SQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					Upsert([]map[string]interface{}{{"email": "a@example.com", "name": "John"}}, []string{"email"}, []string{"name"}).
+					Returning("id").
+					Build()
+			},
+			"MERGE INTO [users] AS [target] USING (VALUES (@p1, @p2)) AS [source] ([email], [name]) ON [target].[email] = [source].[email] WHEN MATCHED THEN UPDATE SET [target].[name] = [source].[name] WHEN NOT MATCHED THEN INSERT ([email], [name]) VALUES ([source].[email], [source].[name]) OUTPUT INSERTED.[id];",
+			[]interface{}{"a@example.com", "John"},
+		},
+		{
+			"Update_PostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "Jane"}).
+					Returning("id", "name").
+					Build()
+			},
+			`UPDATE "users" SET "name" = $1 WHERE "id" = $2 RETURNING "id", "name"`,
+			[]interface{}{"Jane", 1},
+		},
+		{
+			"Update_SQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "Jane"}).
+					Returning("name").
+					Build()
+			},
+			"UPDATE [users] SET [name] = @p1 OUTPUT INSERTED.[name] WHERE [id] = @p2",
+			[]interface{}{"Jane", 1},
+		},
+		{
+			"Delete_SQLite",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(sqlite.NewSQLiteQueryBuilder()).
+					Table("users").
+					Where("id", "=", 1).
+					Returning("id").
+					Build()
+			},
+			`DELETE FROM "users" WHERE "id" = ? RETURNING "id"`,
+			[]interface{}{1},
+		},
+		{
+			"Delete_SQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					Where("id", "=", 1).
+					Returning("id").
+					Build()
+			},
+			"DELETE FROM [users] OUTPUT DELETED.[id] WHERE [id] = @p1",
+			[]interface{}{1},
+		},
+		{
+			"Delete_Join_SQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Where("profiles.active", "=", false).
+					Returning("id").
+					Build()
+			},
+			"DELETE [users] OUTPUT DELETED.[id] FROM [users] INNER JOIN [profiles] ON [users].[id] = [profiles].[user_id] WHERE [profiles].[active] = @p1",
+			[]interface{}{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+
+			if len(values) != len(tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+
+			for i := range values {
+				if values[i] != tt.expectedValues[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expectedValues[i], i, values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReturningApiBuilderMySQL(t *testing.T) {
+	builds := map[string]func() (string, []interface{}, error){
+		"Insert": func() (string, []interface{}, error) {
+			return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+				Table("users").
+				Insert(map[string]interface{}{"name": "John"}).
+				Returning("id").
+				Build()
+		},
+		"Update": func() (string, []interface{}, error) {
+			return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).
+				Table("users").
+				Update(map[string]interface{}{"name": "Jane"}).
+				Returning("id").
+				Build()
+		},
+		"Delete": func() (string, []interface{}, error) {
+			return api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).
+				Table("users").
+				Returning("id").
+				Build()
+		},
+	}
+
+	for name, build := range builds {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := build(); !errors.Is(err, api.ErrReturningNotSupported) {
+				t.Errorf("expected ErrReturningNotSupported but got %v", err)
+			}
+		})
+	}
+}
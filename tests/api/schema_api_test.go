@@ -0,0 +1,318 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+func createUsersTable(t *api.Blueprint) {
+	t.Increments("id")
+	t.String("email").Unique()
+	t.String("name", 100).Nullable()
+	t.Boolean("active").Default(true)
+	t.Decimal("balance", 10, 2).Default(0)
+	t.Enum("role", []string{"admin", "user"}).Default("user")
+	t.JSONB("meta").Nullable()
+	t.UUID("token")
+	t.TimestampTz("created_at").DefaultRaw("CURRENT_TIMESTAMP")
+	t.Foreign("team_id").References("id").On("teams").OnDelete("cascade")
+	t.BigInteger("team_id")
+}
+
+func TestSchemaApiBuilder(t *testing.T) {
+	tests := []struct {
+		name               string
+		strategy           interfaces.QueryBuilderStrategy
+		build              func(sb *api.SchemaBuilder) *api.SchemaBuilder
+		expectedStatements []string
+	}{
+		{
+			"CreateTable_MySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTable("users", createUsersTable)
+			},
+			[]string{
+				"CREATE TABLE `users` (`id` INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY, `email` VARCHAR(255) NOT NULL, `name` VARCHAR(100) NULL, `active` TINYINT(1) NOT NULL DEFAULT 1, `balance` DECIMAL(10, 2) NOT NULL DEFAULT 0, `role` ENUM('admin', 'user') NOT NULL DEFAULT 'user', `meta` JSON NULL, `token` CHAR(36) NOT NULL, `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP, `team_id` BIGINT NOT NULL, CONSTRAINT `users_team_id_foreign` FOREIGN KEY (`team_id`) REFERENCES `teams` (`id`) ON DELETE CASCADE)",
+				"CREATE UNIQUE INDEX `users_email_unique` ON `users` (`email`)",
+			},
+		},
+		{
+			"CreateTable_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTable("users", createUsersTable)
+			},
+			[]string{
+				`CREATE TABLE "users" ("id" SERIAL PRIMARY KEY, "email" VARCHAR(255) NOT NULL, "name" VARCHAR(100) NULL, "active" BOOLEAN NOT NULL DEFAULT TRUE, "balance" DECIMAL(10, 2) NOT NULL DEFAULT 0, "role" VARCHAR(255) NOT NULL DEFAULT 'user' CHECK ("role" IN ('admin', 'user')), "meta" JSONB NULL, "token" UUID NOT NULL, "created_at" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP, "team_id" BIGINT NOT NULL, CONSTRAINT "users_team_id_foreign" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE)`,
+				`CREATE UNIQUE INDEX "users_email_unique" ON "users" ("email")`,
+			},
+		},
+		{
+			"CreateTable_SQLite",
+			sqlite.NewSQLiteQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTable("users", createUsersTable)
+			},
+			[]string{
+				`CREATE TABLE "users" ("id" INTEGER PRIMARY KEY AUTOINCREMENT, "email" VARCHAR(255) NOT NULL, "name" VARCHAR(100) NULL, "active" INTEGER NOT NULL DEFAULT 1, "balance" DECIMAL(10, 2) NOT NULL DEFAULT 0, "role" VARCHAR(255) NOT NULL DEFAULT 'user' CHECK ("role" IN ('admin', 'user')), "meta" TEXT NULL, "token" VARCHAR(36) NOT NULL, "created_at" DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, "team_id" INTEGER NOT NULL, CONSTRAINT "users_team_id_foreign" FOREIGN KEY ("team_id") REFERENCES "teams" ("id") ON DELETE CASCADE)`,
+				`CREATE UNIQUE INDEX "users_email_unique" ON "users" ("email")`,
+			},
+		},
+		{
+			"CreateTable_SQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTable("users", createUsersTable)
+			},
+			[]string{
+				"CREATE TABLE [users] ([id] INT IDENTITY(1,1) NOT NULL PRIMARY KEY, [email] NVARCHAR(255) NOT NULL, [name] NVARCHAR(100) NULL, [active] BIT NOT NULL DEFAULT 1, [balance] DECIMAL(10, 2) NOT NULL DEFAULT 0, [role] NVARCHAR(255) NOT NULL DEFAULT 'user' CHECK ([role] IN ('admin', 'user')), [meta] NVARCHAR(MAX) NULL, [token] UNIQUEIDENTIFIER NOT NULL, [created_at] DATETIMEOFFSET NOT NULL DEFAULT CURRENT_TIMESTAMP, [team_id] BIGINT NOT NULL, CONSTRAINT [users_team_id_foreign] FOREIGN KEY ([team_id]) REFERENCES [teams] ([id]) ON DELETE CASCADE)",
+				"CREATE UNIQUE INDEX [users_email_unique] ON [users] ([email])",
+			},
+		},
+		{
+			"CreateTableIfNotExists_CompositePrimary_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTableIfNotExists("role_user", func(t *api.Blueprint) {
+					t.Integer("role_id")
+					t.Integer("user_id")
+					t.Primary("role_id", "user_id")
+					t.Index([]string{"user_id"})
+				})
+			},
+			[]string{
+				`CREATE TABLE IF NOT EXISTS "role_user" ("role_id" INTEGER NOT NULL, "user_id" INTEGER NOT NULL, PRIMARY KEY ("role_id", "user_id"))`,
+				`CREATE INDEX "role_user_user_id_index" ON "role_user" ("user_id")`,
+			},
+		},
+		{
+			"CreateTableIfNotExists_SQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.CreateTableIfNotExists("tags", func(t *api.Blueprint) {
+					t.BigIncrements("id")
+					t.String("name").Default("it's")
+				})
+			},
+			[]string{
+				"IF OBJECT_ID('tags', 'U') IS NULL CREATE TABLE [tags] ([id] BIGINT IDENTITY(1,1) NOT NULL PRIMARY KEY, [name] NVARCHAR(255) NOT NULL DEFAULT 'it''s')",
+			},
+		},
+		{
+			"Fulltext_MySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.Fulltext([]string{"title", "body"})
+				})
+			},
+			[]string{
+				"CREATE FULLTEXT INDEX `posts_title_body_fulltext` ON `posts` (`title`, `body`)",
+			},
+		},
+		{
+			"Fulltext_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.Fulltext([]string{"title", "body"}, "posts_search")
+				})
+			},
+			[]string{
+				`CREATE INDEX "posts_search" ON "posts" USING GIN ((to_tsvector('english', "title") || to_tsvector('english', "body")))`,
+			},
+		},
+		{
+			"AlterTable_MySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.DropForeign("posts_user_id_foreign")
+					t.DropIndex("posts_title_index")
+					t.RenameColumn("body", "content")
+					t.DropColumn("legacy")
+					t.Text("summary").Nullable()
+					t.Foreign("author_id").References("id").On("users").OnDelete("set null")
+				})
+			},
+			[]string{
+				"ALTER TABLE `posts` DROP FOREIGN KEY `posts_user_id_foreign`",
+				"DROP INDEX `posts_title_index` ON `posts`",
+				"ALTER TABLE `posts` RENAME COLUMN `body` TO `content`",
+				"ALTER TABLE `posts` DROP COLUMN `legacy`",
+				"ALTER TABLE `posts` ADD COLUMN `summary` TEXT NULL",
+				"ALTER TABLE `posts` ADD CONSTRAINT `posts_author_id_foreign` FOREIGN KEY (`author_id`) REFERENCES `users` (`id`) ON DELETE SET NULL",
+			},
+		},
+		{
+			"AlterTable_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.DropForeign("posts_user_id_foreign")
+					t.DropIndex("posts_title_index")
+					t.RenameColumn("body", "content")
+					t.Timestamp("published_at").Nullable()
+				})
+			},
+			[]string{
+				`ALTER TABLE "posts" DROP CONSTRAINT "posts_user_id_foreign"`,
+				`DROP INDEX "posts_title_index"`,
+				`ALTER TABLE "posts" RENAME COLUMN "body" TO "content"`,
+				`ALTER TABLE "posts" ADD COLUMN "published_at" TIMESTAMP NULL`,
+			},
+		},
+		{
+			"AlterTable_SQLite",
+			sqlite.NewSQLiteQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.DropIndex("posts_title_index")
+					t.RenameColumn("body", "content")
+					t.Date("published_on").Nullable()
+				})
+			},
+			[]string{
+				`DROP INDEX "posts_title_index"`,
+				`ALTER TABLE "posts" RENAME COLUMN "body" TO "content"`,
+				`ALTER TABLE "posts" ADD COLUMN "published_on" DATE NULL`,
+			},
+		},
+		{
+			"AlterTable_SQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.AlterTable("posts", func(t *api.Blueprint) {
+					t.DropIndex("posts_title_index")
+					t.RenameColumn("body", "content")
+					t.JSON("meta").Nullable()
+				})
+			},
+			[]string{
+				"DROP INDEX [posts_title_index] ON [posts]",
+				"EXEC sp_rename 'posts.body', 'content', 'COLUMN'",
+				"ALTER TABLE [posts] ADD [meta] NVARCHAR(MAX) NULL",
+			},
+		},
+		{
+			"DropAndRename_MySQL",
+			mysql.NewMySQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.RenameTable("posts", "articles").DropTable("drafts").DropTableIfExists("old")
+			},
+			[]string{
+				"RENAME TABLE `posts` TO `articles`",
+				"DROP TABLE `drafts`",
+				"DROP TABLE IF EXISTS `old`",
+			},
+		},
+		{
+			"DropAndRename_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.RenameTable("posts", "articles").DropTableIfExists("old")
+			},
+			[]string{
+				`ALTER TABLE "posts" RENAME TO "articles"`,
+				`DROP TABLE IF EXISTS "old"`,
+			},
+		},
+		{
+			"DropAndRename_SQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(sb *api.SchemaBuilder) *api.SchemaBuilder {
+				return sb.RenameTable("posts", "articles").DropTableIfExists("old")
+			},
+			[]string{
+				"EXEC sp_rename 'posts', 'articles'",
+				"DROP TABLE IF EXISTS [old]",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			statements, err := tt.build(api.NewSchemaBuilder(tt.strategy)).Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(statements) != len(tt.expectedStatements) {
+				t.Fatalf("expected %d statements but got %d: %q", len(tt.expectedStatements), len(statements), statements)
+			}
+
+			for i := range statements {
+				if statements[i] != tt.expectedStatements[i] {
+					t.Errorf("expected '%s' but got '%s'", tt.expectedStatements[i], statements[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSchemaApiBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		build       func() ([]string, error)
+		unsupported bool
+	}{
+		{
+			"Fulltext_SQLite",
+			func() ([]string, error) {
+				return api.NewSchemaBuilder(sqlite.NewSQLiteQueryBuilder()).AlterTable("posts", func(t *api.Blueprint) {
+					t.Fulltext([]string{"body"})
+				}).Build()
+			},
+			true,
+		},
+		{
+			"AddForeign_SQLite",
+			func() ([]string, error) {
+				return api.NewSchemaBuilder(sqlite.NewSQLiteQueryBuilder()).AlterTable("posts", func(t *api.Blueprint) {
+					t.Foreign("user_id").References("id").On("users")
+				}).Build()
+			},
+			true,
+		},
+		{
+			"InvalidAction",
+			func() ([]string, error) {
+				return api.NewSchemaBuilder(mysql.NewMySQLQueryBuilder()).CreateTable("posts", func(t *api.Blueprint) {
+					t.Foreign("user_id").References("id").On("users").OnDelete("DROP TABLE users")
+				}).Build()
+			},
+			false,
+		},
+		{
+			"EnumWithoutValues",
+			func() ([]string, error) {
+				return api.NewSchemaBuilder(postgres.NewPostgreSQLQueryBuilder()).CreateTable("posts", func(t *api.Blueprint) {
+					t.Enum("status", nil)
+				}).Build()
+			},
+			false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.build()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if errors.Is(err, api.ErrSchemaUnsupported) != tt.unsupported {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+)
+
+// registerUserScopes registers the scopes of the users table used by
+// TestScopeApi.
+func registerUserScopes(strategy interfaces.QueryBuilderStrategy) {
+	api.RegisterGlobalScope(strategy, "users", "tenant", func(q *api.ScopeQueryBuilder) {
+		q.Where("tenant_id", "=", 7)
+	})
+	api.RegisterScope(strategy, "users", "active", func(q *api.ScopeQueryBuilder) {
+		q.Where("active", "=", true)
+	})
+	api.RegisterScope(strategy, "users", "staff", func(q *api.ScopeQueryBuilder) {
+		q.Where("role", "=", "admin").OrWhere("role", "=", "editor")
+	})
+}
+
+func TestScopeApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		strategy       func() interfaces.QueryBuilderStrategy
+		build          func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"GlobalScope",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Build()
+			},
+			`SELECT * FROM "users" WHERE ("tenant_id" = $1)`,
+			[]interface{}{7},
+		},
+		{
+			"GlobalScopeWithOrWhere",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					Where("name", "=", "John").
+					OrWhere("name", "=", "Jane").
+					Build()
+			},
+			`SELECT * FROM "users" WHERE ("name" = $1 OR "name" = $2) AND ("tenant_id" = $3)`,
+			[]interface{}{"John", "Jane", 7},
+		},
+		{
+			"NamedScopes",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users AS u").
+					Scope("active", "staff").
+					Where("age", ">", 18).
+					Build()
+			},
+			`SELECT * FROM "users" as "u" WHERE "age" > $1 AND ("tenant_id" = $2) AND ("active" = $3) AND ("role" = $4 OR "role" = $5)`,
+			[]interface{}{18, 7, true, "admin", "editor"},
+		},
+		{
+			"WithoutGlobalScope",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").
+					WithoutGlobalScope("tenant").
+					Scope("active").
+					Build()
+			},
+			`SELECT * FROM "users" WHERE ("active" = $1)`,
+			[]interface{}{true},
+		},
+		{
+			"OtherTable",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("posts").Where("id", "=", 1).Build()
+			},
+			`SELECT * FROM "posts" WHERE "id" = $1`,
+			[]interface{}{1},
+		},
+		{
+			"Update",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").
+					Where("id", "=", 1).
+					OrWhere("id", "=", 2).
+					Update(map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			"UPDATE `users` SET `name` = ? WHERE (`id` = ? OR `id` = ?) AND (`tenant_id` = ?)",
+			[]interface{}{"John", 1, 2, 7},
+		},
+		{
+			"Delete",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").
+					Scope("staff").
+					Where("id", "=", 1).
+					Build()
+			},
+			"DELETE FROM `users` WHERE `id` = ? AND (`tenant_id` = ?) AND (`role` = ? OR `role` = ?)",
+			[]interface{}{1, 7, "admin", "editor"},
+		},
+		{
+			"DeleteWithoutGlobalScopes",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").
+					WithoutGlobalScopes().
+					Where("id", "=", 1).
+					Build()
+			},
+			"DELETE FROM `users` WHERE `id` = ?",
+			[]interface{}{1},
+		},
+		{
+			"Union",
+			func() interfaces.QueryBuilderStrategy { return postgres.NewPostgreSQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Select("id").
+					Union(api.NewSelectQueryBuilder(strategy).Table("users").Select("id").Where("id", "=", 1)).
+					Build()
+			},
+			`SELECT "id" FROM "users" WHERE "id" = $1 AND ("tenant_id" = $2) UNION SELECT "id" FROM "users" WHERE ("tenant_id" = $3)`,
+			[]interface{}{1, 7, 7},
+		},
+		{
+			"WhereInSubQuery",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("orders").
+					WhereIn("user_id", api.NewSelectQueryBuilder(strategy).Table("users").Select("id").Scope("active")).
+					Build()
+			},
+			"SELECT * FROM `orders` WHERE `user_id` IN (SELECT `id` FROM `users` WHERE (`tenant_id` = ?) AND (`active` = ?))",
+			[]interface{}{7, true},
+		},
+		{
+			"WhereExistsSubQuery",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("orders").
+					WhereExists(func(q *api.SelectQueryBuilder) {
+						q.Table("users").Where("active", "=", true)
+					}).
+					Build()
+			},
+			"SELECT * FROM `orders` WHERE EXISTS (SELECT * FROM `users` WHERE `active` = ? AND (`tenant_id` = ?))",
+			[]interface{}{true, 7},
+		},
+		{
+			"CTE",
+			func() interfaces.QueryBuilderStrategy { return mysql.NewMySQLQueryBuilder() },
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).
+					With("u", api.NewSelectQueryBuilder(strategy).Table("users").Select("id")).
+					Table("u").
+					Build()
+			},
+			"WITH `u` AS (SELECT `id` FROM `users` WHERE (`tenant_id` = ?)) SELECT * FROM `u`",
+			[]interface{}{7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := tt.strategy()
+			registerUserScopes(strategy)
+
+			query, values, err := tt.build(strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestScopeApiUnknownScope(t *testing.T) {
+	strategy := postgres.NewPostgreSQLQueryBuilder()
+	registerUserScopes(strategy)
+
+	_, _, err := api.NewSelectQueryBuilder(strategy).Table("posts").Scope("active").Build()
+	if !errors.Is(err, api.ErrUnknownScope) {
+		t.Errorf("expected ErrUnknownScope but got %v", err)
+	}
+
+	// the scope is looked up on the table set before Scope
+	_, _, err = api.NewDeleteQueryBuilder(strategy).Scope("active").Table("users").Build()
+	if !errors.Is(err, api.ErrUnknownScope) {
+		t.Errorf("expected ErrUnknownScope but got %v", err)
+	}
+}
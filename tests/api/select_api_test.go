@@ -34,7 +34,7 @@ func TestSelectApiBuilder(t *testing.T) {
 		{
 			"Count",
 			func() *api.SelectQueryBuilder {
-				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Count()
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).SelectCount()
 			},
 			"SELECT COUNT(*) FROM ``",
 			nil,
@@ -42,7 +42,7 @@ func TestSelectApiBuilder(t *testing.T) {
 		{
 			"Count_Columns",
 			func() *api.SelectQueryBuilder {
-				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Count("id")
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).SelectCount("id")
 			},
 			"SELECT COUNT(`id`) FROM ``",
 			nil,
@@ -50,7 +50,7 @@ func TestSelectApiBuilder(t *testing.T) {
 		{
 			"Count_Distinct",
 			func() *api.SelectQueryBuilder {
-				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Count("id").Distinct("id")
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).SelectCount("id").Distinct("id")
 			},
 			"SELECT COUNT(DISTINCT `id`) FROM ``",
 			nil,
@@ -58,7 +58,7 @@ func TestSelectApiBuilder(t *testing.T) {
 		{
 			"Count_Distinct_Columns",
 			func() *api.SelectQueryBuilder {
-				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Distinct("id", "name").Count("id", "name")
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Distinct("id", "name").SelectCount("id", "name")
 			},
 			"SELECT COUNT(DISTINCT `id`), COUNT(DISTINCT `name`) FROM ``",
 			nil,
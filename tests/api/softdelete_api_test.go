@@ -0,0 +1,192 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
+)
+
+func TestSoftDeleteApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Select",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Where("id", "=", 1).Build()
+			},
+			`SELECT * FROM "users" WHERE "id" = $1 AND ("users"."deleted_at" IS NULL)`,
+			[]interface{}{1},
+		},
+		{
+			"SelectWithTrashed",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Where("id", "=", 1).WithTrashed().Build()
+			},
+			`SELECT * FROM "users" WHERE "id" = $1`,
+			[]interface{}{1},
+		},
+		{
+			"SelectOnlyTrashed",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").OnlyTrashed().Build()
+			},
+			`SELECT * FROM "users" WHERE ("users"."deleted_at" IS NOT NULL)`,
+			nil,
+		},
+		{
+			"Update",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			`UPDATE "users" SET "name" = $1 WHERE "id" = $2 AND ("users"."deleted_at" IS NULL)`,
+			[]interface{}{"John", 1},
+		},
+		{
+			"Delete",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).Build()
+			},
+			`DELETE FROM "users" WHERE "id" = $1 AND ("users"."deleted_at" IS NULL)`,
+			[]interface{}{1},
+		},
+		{
+			"ForceDelete",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).ForceDelete().Build()
+			},
+			`DELETE FROM "users" WHERE "id" = $1`,
+			[]interface{}{1},
+		},
+		{
+			"ForceDeleteOnlyTrashed",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").OnlyTrashed().ForceDelete().Build()
+			},
+			`DELETE FROM "users" WHERE ("users"."deleted_at" IS NOT NULL)`,
+			nil,
+		},
+		{
+			"Restore",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).Restore().Returning("id").Build()
+			},
+			`UPDATE "users" SET "deleted_at" = $1 WHERE "id" = $2 AND ("users"."deleted_at" IS NOT NULL) RETURNING "id"`,
+			[]interface{}{nil, 1},
+		},
+		{
+			"Join",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("posts").
+					Join("users", "users.id", "=", "posts.user_id").
+					Where("users.id", "=", 1).
+					Build()
+			},
+			`SELECT "users".*, "posts".* FROM "posts" INNER JOIN "users" ON "users"."id" = "posts"."user_id" WHERE "users"."id" = $1 AND ("posts"."deleted_at" IS NULL)`,
+			[]interface{}{1},
+		},
+		{
+			"JoinWithAlias",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users AS u").
+					Join("posts", "u.id", "=", "posts.user_id").
+					Build()
+			},
+			`SELECT "posts".*, "u".* FROM "users" as "u" INNER JOIN "posts" ON "u"."id" = "posts"."user_id" WHERE ("u"."deleted_at" IS NULL)`,
+			nil,
+		},
+		{
+			"SubQuery",
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("orders").
+					WhereIn("user_id", api.NewSelectQueryBuilder(strategy).Table("users").Select("id")).
+					Build()
+			},
+			`SELECT * FROM "orders" WHERE "user_id" IN (SELECT "id" FROM "users" WHERE ("users"."deleted_at" IS NULL))`,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy := postgres.NewPostgreSQLQueryBuilder()
+			api.RegisterSoftDeletes(strategy, "users", "deleted_at")
+			api.RegisterSoftDeletes(strategy, "posts", "deleted_at")
+
+			query, values, err := tt.build(strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteApiSoftDelete(t *testing.T) {
+	strategy := postgres.NewPostgreSQLQueryBuilder()
+	api.RegisterSoftDeletes(strategy, "users", "deleted_at")
+
+	before := time.Now()
+	query, values, err := api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).SoftDelete().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `UPDATE "users" SET "deleted_at" = $1 WHERE "id" = $2 AND ("users"."deleted_at" IS NULL)`
+	if query != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, query)
+	}
+	if len(values) != 2 || values[1] != 1 {
+		t.Fatalf("unexpected values %v", values)
+	}
+	if at, ok := values[0].(time.Time); !ok || at.Before(before) {
+		t.Errorf("expected the current time but got %v", values[0])
+	}
+
+	_, _, err = api.NewDeleteQueryBuilder(strategy).Table("posts").SoftDelete().Build()
+	if !errors.Is(err, api.ErrNoSoftDeletes) {
+		t.Errorf("expected ErrNoSoftDeletes but got %v", err)
+	}
+}
+
+func TestSoftDeleteApiTimestamps(t *testing.T) {
+	strategy := postgres.NewPostgreSQLQueryBuilder()
+	api.RegisterSoftDeletes(strategy, "users", "deleted_at")
+
+	// every read of the clock returns a later time
+	var ticks int64
+	api.RegisterTimestamps(strategy, "users", timestamp.Options{Clock: func() time.Time {
+		ticks++
+		return time.Unix(ticks, 0)
+	}})
+
+	query, values, err := api.NewDeleteQueryBuilder(strategy).Table("users").Where("id", "=", 1).SoftDelete().Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `UPDATE "users" SET "deleted_at" = $1, "updated_at" = $2 WHERE "id" = $3 AND ("users"."deleted_at" IS NULL)`
+	if query != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, query)
+	}
+	if len(values) != 3 || values[0] != values[1] {
+		t.Errorf("expected equal deleted_at and updated_at but got %v", values)
+	}
+}
@@ -0,0 +1,181 @@
+package api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestSourceApi(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"UpdateJoinPostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Where("profiles.active", "=", true).
+					OrWhere("profiles.admin", "=", true).
+					Update(map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			`UPDATE "users" SET "name" = $1 FROM "profiles" WHERE "users"."id" = "profiles"."user_id" AND ("profiles"."active" = $2 OR "profiles"."admin" = $3)`,
+			[]interface{}{"John", true, true},
+		},
+		{
+			"UpdateJoinQuerySQLite",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlite.NewSQLiteQueryBuilder()).Table("users").
+					JoinQuery("profiles", func(j *api.JoinClauseQueryBuilder) {
+						j.On("users.id", "=", "profiles.user_id").Where("profiles.active", "=", true)
+					}).
+					Where("users.id", ">", 10).
+					Update(map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			`UPDATE "users" SET "name" = ? FROM "profiles" WHERE "users"."id" = "profiles"."user_id" AND "profiles"."active" = ? AND "users"."id" > ?`,
+			[]interface{}{"John", true, 10},
+		},
+		{
+			"UpdateJoinQualifiedSetPostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Update(map[string]interface{}{"users.name": "John", "users.data->theme": "dark"}).
+					Build()
+			},
+			`UPDATE "users" SET "data" = jsonb_set("users"."data", '{theme}', $1), "name" = $2 FROM "profiles" WHERE "users"."id" = "profiles"."user_id"`,
+			[]interface{}{"dark", "John"},
+		},
+		{
+			"UpdateJoinAliasedSetSQLite",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlite.NewSQLiteQueryBuilder()).Table("users AS u").
+					Join("profiles", "u.id", "=", "profiles.user_id").
+					Increment("u.score", 1).
+					Build()
+			},
+			`UPDATE "users" as "u" SET "score" = "u"."score" + ? FROM "profiles" WHERE "u"."id" = "profiles"."user_id"`,
+			[]interface{}{1},
+		},
+		{
+			"UpdateFromSubPostgreSQL",
+			func() (string, []interface{}, error) {
+				totals := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("orders").
+					Select("user_id").
+					Where("status", "=", "paid")
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					FromSub(totals, "o").
+					WhereRaw(`"o"."user_id" = "users"."id"`, nil).
+					Update(map[string]interface{}{"vip": true}).
+					Build()
+			},
+			`UPDATE "users" SET "vip" = $1 FROM (SELECT "user_id" FROM "orders" WHERE "status" = $2) AS "o" WHERE "o"."user_id" = "users"."id"`,
+			[]interface{}{true, "paid"},
+		},
+		{
+			"UpdateJoinFromMySQL",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					From("settings").
+					Where("settings.enabled", "=", true).
+					Update(map[string]interface{}{"users.name": "John"}).
+					Build()
+			},
+			"UPDATE `users` INNER JOIN `profiles` ON `users`.`id` = `profiles`.`user_id`, `settings` SET `users`.`name` = ? WHERE `settings`.`enabled` = ?",
+			[]interface{}{"John", true},
+		},
+		{
+			"UpdateJoinSQLServer",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Where("profiles.active", "=", true).
+					Update(map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			`UPDATE [users] SET [name] = @p1 FROM [users] INNER JOIN [profiles] ON [users].[id] = [profiles].[user_id] WHERE [profiles].[active] = @p2`,
+			[]interface{}{"John", true},
+		},
+		{
+			"DeleteJoinPostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Join("profiles", "users.id", "=", "profiles.user_id").
+					Where("profiles.active", "=", false).
+					Returning("id").
+					Build()
+			},
+			`DELETE FROM "users" USING "profiles" WHERE "users"."id" = "profiles"."user_id" AND "profiles"."active" = $1 RETURNING "id"`,
+			[]interface{}{false},
+		},
+		{
+			"DeleteUsingPostgreSQL",
+			func() (string, []interface{}, error) {
+				return api.NewDeleteQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+					Using("banned").
+					WhereRaw(`"banned"."email" = "users"."email"`, nil).
+					Build()
+			},
+			`DELETE FROM "users" USING "banned" WHERE "banned"."email" = "users"."email"`,
+			nil,
+		},
+		{
+			"DeleteUsingSubMySQL",
+			func() (string, []interface{}, error) {
+				banned := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("bans").
+					Select("user_id").
+					Where("active", "=", true)
+				return api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("users").
+					UsingSub(banned, "b").
+					WhereRaw("`b`.`user_id` = `users`.`id`", nil).
+					Build()
+			},
+			"DELETE `users` FROM `users`, (SELECT `user_id` FROM `bans` WHERE `active` = ?) AS `b` WHERE `b`.`user_id` = `users`.`id`",
+			[]interface{}{true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestSourceApiJoinNotSupported(t *testing.T) {
+	_, _, err := api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").
+		LeftJoin("profiles", "users.id", "=", "profiles.user_id").
+		Update(map[string]interface{}{"name": "John"}).
+		Build()
+	if !errors.Is(err, api.ErrJoinNotSupported) {
+		t.Errorf("expected ErrJoinNotSupported but got %v", err)
+	}
+
+	_, _, err = api.NewDeleteQueryBuilder(sqlite.NewSQLiteQueryBuilder()).Table("users").
+		Join("profiles", "users.id", "=", "profiles.user_id").
+		Build()
+	if !errors.Is(err, api.ErrJoinNotSupported) {
+		t.Errorf("expected ErrJoinNotSupported but got %v", err)
+	}
+}
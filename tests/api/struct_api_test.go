@@ -0,0 +1,197 @@
+package api_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+type Timestamps struct {
+	CreatedAt string `db:"created_at,readonly"`
+}
+
+type User struct {
+	ID       int64  `db:"id,pk"`
+	Name     string `db:"name"`
+	Email    string `db:"email,omitempty"`
+	Internal string `db:"-"`
+	Age      int
+	Timestamps
+}
+
+func TestStructApiBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"InsertStruct",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					InsertStruct(&User{Name: "John", Age: 30, Internal: "x", Timestamps: Timestamps{CreatedAt: "now"}}).
+					Build()
+			},
+			"INSERT INTO `users` (`age`, `name`) VALUES (?, ?)",
+			[]interface{}{30, "John"},
+		},
+		{
+			"InsertStruct_WithPK",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					InsertStruct(User{ID: 3, Name: "John", Email: "john@example.com"}).
+					Build()
+			},
+			"INSERT INTO `users` (`age`, `email`, `id`, `name`) VALUES (?, ?, ?, ?)",
+			[]interface{}{0, "john@example.com", int64(3), "John"},
+		},
+		{
+			"InsertStructs",
+			func() (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("users").
+					InsertStructs([]User{{Name: "John", Age: 30}, {Name: "Jane", Age: 25, Email: "jane@example.com"}}).
+					Build()
+			},
+			`INSERT INTO "users" ("age", "email", "name") VALUES ($1, $2, $3), ($4, $5, $6)`,
+			[]interface{}{30, nil, "John", 25, "jane@example.com", "Jane"},
+		},
+		{
+			"UpdateStruct",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					UpdateStruct(&User{ID: 1, Name: "Jane", Age: 25}).
+					Build()
+			},
+			"UPDATE `users` SET `age` = ?, `name` = ? WHERE `id` = ?",
+			[]interface{}{25, "Jane", int64(1)},
+		},
+		{
+			"UpdateStruct_Options",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("users").
+					UpdateStruct(&User{ID: 1, Name: "Jane", Email: "jane@example.com"}, api.UpdateStructOptions{Columns: []string{"name", "email", "age"}, OmitZero: true}).
+					Build()
+			},
+			"UPDATE `users` SET `email` = ?, `name` = ? WHERE `id` = ?",
+			[]interface{}{"jane@example.com", "Jane", int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestStructApiBuilderInvalid(t *testing.T) {
+	_, _, err := api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		Table("users").
+		InsertStruct(42).
+		Build()
+	if err == nil {
+		t.Error("expected an error for a non struct value")
+	}
+}
+
+func TestStructApiUpdateWithoutKey(t *testing.T) {
+	_, _, err := api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		Table("profiles").
+		UpdateStruct(&Profile{Bio: "Hello"}).
+		Build()
+	if !errors.Is(err, api.ErrNoKey) {
+		t.Errorf("expected ErrNoKey but got %v", err)
+	}
+
+	query, _, err := api.NewUpdateQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		Table("profiles").
+		UpdateStruct(&Profile{Bio: "Hello"}).
+		Where("user_id", "=", 1).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UPDATE `profiles` SET `bio` = ? WHERE `user_id` = ?"
+	if query != expected {
+		t.Errorf("expected '%s' but got '%s'", expected, query)
+	}
+}
+
+type Profile struct {
+	Bio string `db:"bio"`
+}
+
+type UserWithProfile struct {
+	ID   int64  `db:"id"`
+	Name string `db:"users.name"`
+	*Profile
+}
+
+func TestStructApiScan(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	rec.AddRows([]string{"id", "name", "bio", "unknown"},
+		[]driver.Value{int64(1), []byte("John"), "hello", "x"},
+		[]driver.Value{int64(2), "Jane", "world", "y"},
+	)
+
+	var users []*UserWithProfile
+	err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		SetQueryer(db).
+		Table("users").
+		Select("users.id", "users.name as name", "profiles.bio", "unknown").
+		Join("profiles", "users.id", "=", "profiles.user_id").
+		Scan(context.Background(), &users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []*UserWithProfile{
+		{ID: 1, Name: "John", Profile: &Profile{Bio: "hello"}},
+		{ID: 2, Name: "Jane", Profile: &Profile{Bio: "world"}},
+	}
+	if !reflect.DeepEqual(users, expected) {
+		t.Errorf("expected %+v but got %+v", expected, users)
+	}
+
+	rec.AddRows([]string{"id", "name", "age"}, []driver.Value{int64(3), "Alice", int64(40)})
+
+	var plain []User
+	err = api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		SetQueryer(db).
+		Table("users").
+		Scan(context.Background(), &plain)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plain) != 1 || plain[0].ID != 3 || plain[0].Name != "Alice" || plain[0].Age != 40 {
+		t.Errorf("unexpected result %+v", plain)
+	}
+}
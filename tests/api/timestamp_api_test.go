@@ -0,0 +1,142 @@
+package api_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/timestamp"
+)
+
+func TestTimestampApi(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := timestamp.Options{Clock: func() time.Time { return now }}
+
+	tests := []struct {
+		name           string
+		strategy       interfaces.QueryBuilderStrategy
+		opts           timestamp.Options
+		build          func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Insert",
+			postgres.NewPostgreSQLQueryBuilder(),
+			clock,
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").Insert(map[string]interface{}{"name": "John"}).Build()
+			},
+			`INSERT INTO "users" ("created_at", "name", "updated_at") VALUES ($1, $2, $3)`,
+			[]interface{}{now, "John", now},
+		},
+		{
+			"InsertBatchKeepsCallerValues",
+			mysql.NewMySQLQueryBuilder(),
+			clock,
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").InsertBatch([]map[string]interface{}{
+					{"name": "John"},
+					{"name": "Jane", "created_at": "2020-01-01"},
+				}).Build()
+			},
+			"INSERT INTO `users` (`created_at`, `name`, `updated_at`) VALUES (?, ?, ?), (?, ?, ?)",
+			[]interface{}{now, "John", now, "2020-01-01", "Jane", now},
+		},
+		{
+			"Upsert",
+			postgres.NewPostgreSQLQueryBuilder(),
+			clock,
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").
+					Upsert([]map[string]interface{}{{"email": "john@example.com", "name": "John"}}, []string{"email"}, []string{"name"}).
+					Build()
+			},
+			`INSERT INTO "users" ("created_at", "email", "name", "updated_at") VALUES ($1, $2, $3, $4) ON CONFLICT ("email") DO UPDATE SET "name" = EXCLUDED."name", "updated_at" = EXCLUDED."updated_at"`,
+			[]interface{}{now, "john@example.com", "John", now},
+		},
+		{
+			"UpdateOrInsertDatabaseClock",
+			sqlserver.NewSQLServerQueryBuilder(),
+			timestamp.Options{DatabaseClock: true},
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").
+					UpdateOrInsert(map[string]interface{}{"email": "john@example.com"}, map[string]interface{}{"name": "John"}).
+					Build()
+			},
+			`MERGE INTO [users] AS [target] USING (VALUES (CURRENT_TIMESTAMP, @p1, @p2, CURRENT_TIMESTAMP)) AS [source] ([created_at], [email], [name], [updated_at]) ON [target].[email] = [source].[email] WHEN MATCHED THEN UPDATE SET [target].[name] = [source].[name], [target].[updated_at] = [source].[updated_at] WHEN NOT MATCHED THEN INSERT ([created_at], [email], [name], [updated_at]) VALUES ([source].[created_at], [source].[email], [source].[name], [source].[updated_at]);`,
+			[]interface{}{"john@example.com", "John"},
+		},
+		{
+			"Update",
+			postgres.NewPostgreSQLQueryBuilder(),
+			clock,
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "Jane"}).
+					Build()
+			},
+			`UPDATE "users" SET "name" = $1, "updated_at" = $2 WHERE "id" = $3`,
+			[]interface{}{"Jane", now, 1},
+		},
+		{
+			"UpdateDatabaseClock",
+			mysql.NewMySQLQueryBuilder(),
+			timestamp.Options{DatabaseClock: true},
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"name": "Jane"}).
+					Build()
+			},
+			"UPDATE `users` SET `name` = ?, `updated_at` = CURRENT_TIMESTAMP WHERE `id` = ?",
+			[]interface{}{"Jane", 1},
+		},
+		{
+			"CustomColumns",
+			postgres.NewPostgreSQLQueryBuilder(),
+			timestamp.Options{CreatedAt: "-", UpdatedAt: "modified", Clock: clock.Clock},
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewInsertQueryBuilder(strategy).Table("users").Insert(map[string]interface{}{"name": "John"}).Build()
+			},
+			`INSERT INTO "users" ("modified", "name") VALUES ($1, $2)`,
+			[]interface{}{now, "John"},
+		},
+		{
+			"OtherTable",
+			postgres.NewPostgreSQLQueryBuilder(),
+			clock,
+			func(strategy interfaces.QueryBuilderStrategy) (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("posts").
+					Where("id", "=", 1).
+					Update(map[string]interface{}{"title": "Hello"}).
+					Build()
+			},
+			`UPDATE "posts" SET "title" = $1 WHERE "id" = $2`,
+			[]interface{}{"Hello", 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api.RegisterTimestamps(tt.strategy, "users", tt.opts)
+
+			query, values, err := tt.build(tt.strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/internal/db/interfaces"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+// mysqlError has the shape of *mysql.MySQLError.
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+// pgError has the shape of *pgconn.PgError.
+type pgError struct {
+	Code string
+}
+
+func (e *pgError) Error() string    { return "ERROR: " + e.Code }
+func (e *pgError) SQLState() string { return e.Code }
+
+func TestTransactionApi(t *testing.T) {
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name            string
+		strategy        interfaces.QueryBuilderStrategy
+		fn              func(ctx context.Context, tx *api.Tx) error
+		expectedErr     error
+		expectedQueries []string
+	}{
+		{
+			"Commit",
+			mysql.NewMySQLQueryBuilder(),
+			func(ctx context.Context, tx *api.Tx) error {
+				_, err := tx.NewInsertQueryBuilder().Table("users").Insert(map[string]interface{}{"name": "John"}).Exec(ctx)
+				return err
+			},
+			nil,
+			[]string{"BEGIN", "INSERT INTO `users` (`name`) VALUES (?)", "COMMIT"},
+		},
+		{
+			"Rollback",
+			mysql.NewMySQLQueryBuilder(),
+			func(ctx context.Context, tx *api.Tx) error {
+				if _, err := tx.NewDeleteQueryBuilder().Table("users").Where("id", "=", 1).Exec(ctx); err != nil {
+					return err
+				}
+				return errFailed
+			},
+			errFailed,
+			[]string{"BEGIN", "DELETE FROM `users` WHERE `id` = ?", "ROLLBACK"},
+		},
+		{
+			"Savepoints_PostgreSQL",
+			postgres.NewPostgreSQLQueryBuilder(),
+			func(ctx context.Context, tx *api.Tx) error {
+				if err := tx.Transaction(ctx, func(tx *api.Tx) error {
+					_, err := tx.NewUpdateQueryBuilder().Table("users").Update(map[string]interface{}{"name": "Jane"}).Exec(ctx)
+					return err
+				}); err != nil {
+					return err
+				}
+
+				if err := tx.Transaction(ctx, func(tx *api.Tx) error {
+					return errFailed
+				}); !errors.Is(err, errFailed) {
+					return fmt.Errorf("unexpected savepoint error: %v", err)
+				}
+				return nil
+			},
+			nil,
+			[]string{
+				"BEGIN",
+				"SAVEPOINT savepoint_1",
+				`UPDATE "users" SET "name" = $1`,
+				"RELEASE SAVEPOINT savepoint_1",
+				"SAVEPOINT savepoint_2",
+				"ROLLBACK TO SAVEPOINT savepoint_2",
+				"COMMIT",
+			},
+		},
+		{
+			"Savepoints_SQLServer",
+			sqlserver.NewSQLServerQueryBuilder(),
+			func(ctx context.Context, tx *api.Tx) error {
+				_ = tx.Transaction(ctx, func(tx *api.Tx) error { return nil })
+				_ = tx.Transaction(ctx, func(tx *api.Tx) error { return errFailed })
+				return nil
+			},
+			nil,
+			[]string{
+				"BEGIN",
+				"SAVE TRANSACTION savepoint_1",
+				"SAVE TRANSACTION savepoint_2",
+				"ROLLBACK TRANSACTION savepoint_2",
+				"COMMIT",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, rec := fakedb.Open()
+			defer db.Close()
+			ctx := context.Background()
+
+			err := api.Transaction(ctx, db, tt.strategy, func(tx *api.Tx) error {
+				return tt.fn(ctx, tx)
+			})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("expected error %v but got %v", tt.expectedErr, err)
+			}
+
+			if queries := rec.Queries(); !reflect.DeepEqual(queries, tt.expectedQueries) {
+				t.Errorf("expected %q but got %q", tt.expectedQueries, queries)
+			}
+		})
+	}
+}
+
+func TestTransactionApiPanic(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	defer func() {
+		if p := recover(); p != "boom" {
+			t.Errorf("expected the panic to be re-raised but got %v", p)
+		}
+		if queries := rec.Queries(); !reflect.DeepEqual(queries, []string{"BEGIN", "ROLLBACK"}) {
+			t.Errorf("expected a rollback but got %q", queries)
+		}
+	}()
+
+	_ = api.Transaction(context.Background(), db, mysql.NewMySQLQueryBuilder(), func(tx *api.Tx) error {
+		panic("boom")
+	})
+}
+
+func TestTransactionApiRetry(t *testing.T) {
+	tests := []struct {
+		name             string
+		errs             []error
+		maxRetries       int
+		expectedAttempts int
+		expectedErr      bool
+	}{
+		{"RetriedDeadlock", []error{&mysqlError{Number: 1213}}, 2, 2, false},
+		{"RetriedSerializationFailure", []error{&pgError{Code: "40001"}, &pgError{Code: "40P01"}}, 2, 3, false},
+		{"RetriesExhausted", []error{&mysqlError{Number: 1205}, &mysqlError{Number: 1205}}, 1, 2, true},
+		{"NotRetryable", []error{&mysqlError{Number: 1062}}, 3, 1, true},
+		{"NoRetries", []error{&mysqlError{Number: 1213}}, 0, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, _ := fakedb.Open()
+			defer db.Close()
+
+			attempts := 0
+			err := api.Transaction(context.Background(), db, mysql.NewMySQLQueryBuilder(), func(tx *api.Tx) error {
+				attempts++
+				if attempts <= len(tt.errs) {
+					return tt.errs[attempts-1]
+				}
+				return nil
+			}, api.TxOptions{MaxRetries: tt.maxRetries})
+
+			if (err != nil) != tt.expectedErr {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts but got %d", tt.expectedAttempts, attempts)
+			}
+		})
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"MySQLDeadlock", &mysqlError{Number: 1213}, true},
+		{"MySQLLockWaitTimeout", &mysqlError{Number: 1205}, true},
+		{"MySQLDuplicate", &mysqlError{Number: 1062}, false},
+		{"PostgreSQLSerialization", &pgError{Code: "40001"}, true},
+		{"PostgreSQLDeadlock", &pgError{Code: "40P01"}, true},
+		{"PostgreSQLUniqueViolation", &pgError{Code: "23505"}, false},
+		{"Wrapped", fmt.Errorf("insert: %w", &mysqlError{Number: 1213}), true},
+		{"Plain", errors.New("deadlock"), false},
+		{"Nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := api.IsRetryableError(tt.err); got != tt.expected {
+				t.Errorf("expected %v but got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLockOutsideTransactionWarning(t *testing.T) {
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	db, _ := fakedb.Open()
+	defer db.Close()
+	ctx := context.Background()
+	strategy := mysql.NewMySQLQueryBuilder()
+
+	if _, err := api.NewSelectQueryBuilder(strategy).SetQueryer(db).Table("users").LockForUpdate().Get(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "FOR UPDATE used outside a transaction") {
+		t.Errorf("expected a warning but got %q", out.String())
+	}
+
+	out.Reset()
+	err := api.Transaction(ctx, db, strategy, func(tx *api.Tx) error {
+		_, err := tx.NewSelectQueryBuilder().Table("users").SharedLock().Get(ctx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("expected no warning but got %q", out.String())
+	}
+}
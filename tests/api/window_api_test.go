@@ -0,0 +1,196 @@
+package api_test
+
+import (
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+)
+
+func TestWindowApiBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		setup          func() *api.SelectQueryBuilder
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"RowNumber",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("employees").
+					Select("id").
+					SelectWindow("ROW_NUMBER()", "rn", func(w *api.WindowBuilder) {
+						w.PartitionBy("department_id").OrderBy("salary", "desc")
+					})
+			},
+			"SELECT `id`, ROW_NUMBER() OVER (PARTITION BY `department_id` ORDER BY `salary` DESC) as `rn` FROM `employees`",
+			nil,
+		},
+		{
+			"Rank_Without_Parentheses",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("scores").
+					SelectWindow("RANK", "position", func(w *api.WindowBuilder) {
+						w.OrderBy("points", "desc")
+					})
+			},
+			`SELECT RANK() OVER (ORDER BY "points" DESC) as "position" FROM "scores"`,
+			nil,
+		},
+		{
+			"Lag_With_Literal_Arguments",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("prices").
+					Select("day").
+					SelectWindow("LAG(prices.price, 1, 0)", "previous_price", func(w *api.WindowBuilder) {
+						w.OrderBy("day", "asc")
+					})
+			},
+			`SELECT "day", LAG("prices"."price", 1, 0) OVER (ORDER BY "day" ASC) as "previous_price" FROM "prices"`,
+			nil,
+		},
+		{
+			"Sum_With_Frame",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(amount)", "running_total", func(w *api.WindowBuilder) {
+						w.PartitionBy("account_id").OrderBy("paid_at", "asc").RowsBetween("unbounded preceding", "current row")
+					}).
+					Where("account_id", "=", 3)
+			},
+			"SELECT SUM(`amount`) OVER (PARTITION BY `account_id` ORDER BY `paid_at` ASC ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) as `running_total` FROM `payments` WHERE `account_id` = ?",
+			[]interface{}{3},
+		},
+		{
+			"Raw_Distinct",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("orders").
+					SelectWindowRaw("COUNT(DISTINCT product_id)", "products", func(w *api.WindowBuilder) {
+						w.PartitionBy("customer_id")
+					})
+			},
+			`SELECT COUNT(DISTINCT product_id) OVER (PARTITION BY "customer_id") as "products" FROM "orders"`,
+			nil,
+		},
+		{
+			"Named_Window",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(amount)", "total", func(w *api.WindowBuilder) {
+						w.Window("w")
+					}).
+					SelectWindow("AVG(amount)", "moving_avg", func(w *api.WindowBuilder) {
+						w.Window("w").RowsBetween("2 PRECEDING", "CURRENT ROW")
+					}).
+					Window("w", func(w *api.WindowBuilder) {
+						w.PartitionBy("account_id").OrderBy("paid_at", "asc")
+					}).
+					GroupBy("account_id", "paid_at", "amount").
+					OrderBy("paid_at", "desc")
+			},
+			`SELECT SUM("amount") OVER "w" as "total", AVG("amount") OVER ("w" ROWS BETWEEN 2 PRECEDING AND CURRENT ROW) as "moving_avg" FROM "payments" GROUP BY "account_id", "paid_at", "amount" WINDOW "w" AS (PARTITION BY "account_id" ORDER BY "paid_at" ASC) ORDER BY "paid_at" DESC`,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := tt.setup()
+			query, values, err := builder.Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+
+			if len(values) != len(tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+
+			for i := range values {
+				if values[i] != tt.expectedValues[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expectedValues[i], i, values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWindowApiBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func() *api.SelectQueryBuilder
+	}{
+		{
+			"InvalidFrameBound",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(amount)", "total", func(w *api.WindowBuilder) {
+						w.RowsBetween("1; DROP TABLE payments", "CURRENT ROW")
+					})
+			},
+		},
+		{
+			"DistinctArgument",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(DISTINCT amount)", "total", nil)
+			},
+		},
+		{
+			"ExpressionArgument",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(amount*2)", "total", nil)
+			},
+		},
+		{
+			"DotArgument",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("NTH_VALUE(amount, .)", "total", nil)
+			},
+		},
+		{
+			"NegativeDotArgument",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("NTH_VALUE(amount, -.)", "total", nil)
+			},
+		},
+		{
+			"InvalidFunctionName",
+			func() *api.SelectQueryBuilder {
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					Table("payments").
+					SelectWindow("SUM(amount); --", "total", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, _, err := tt.setup().Build(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+)
+
+func TestWithApiBuilder(t *testing.T) {
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"With",
+			func() (string, []interface{}, error) {
+				recent := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("orders").Select("user_id").Where("total", ">", 100)
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					With("big_spenders", recent).
+					Table("users").
+					WhereIn("id", []interface{}{1, 2}).
+					Build()
+			},
+			"WITH `big_spenders` AS (SELECT `user_id` FROM `orders` WHERE `total` > ?) SELECT * FROM `users` WHERE `id` IN (?, ?)",
+			[]interface{}{100, 1, 2},
+		},
+		{
+			"WithRecursive_PostgreSQL",
+			func() (string, []interface{}, error) {
+				anchor := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("categories").Select("id", "parent_id").Where("id", "=", 1)
+				recursive := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("categories AS c").Select("c.id", "c.parent_id").Join("tree", "tree.id", "=", "c.parent_id")
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					WithRecursive("tree", []string{"id", "parent_id"}, anchor, recursive).
+					Table("tree").
+					Where("id", "!=", 7).
+					Build()
+			},
+			`WITH RECURSIVE "tree" ("id", "parent_id") AS (SELECT "id", "parent_id" FROM "categories" WHERE "id" = $1 UNION ALL SELECT "c"."id", "c"."parent_id" FROM "categories" as "c" INNER JOIN "tree" ON "tree"."id" = "c"."parent_id") SELECT * FROM "tree" WHERE "id" != $2`,
+			[]interface{}{1, 7},
+		},
+		{
+			"WithMaterialized_PostgreSQL_Union",
+			func() (string, []interface{}, error) {
+				recent := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("orders").Select("user_id").Where("total", ">", 100)
+				admins := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("admins").Where("active", "=", true)
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					WithMaterialized("recent", recent).
+					Table("users").
+					Where("id", "=", 5).
+					Union(admins).
+					Build()
+			},
+			`WITH "recent" AS MATERIALIZED (SELECT "user_id" FROM "orders" WHERE "total" > $1) SELECT * FROM "admins" WHERE "active" = $2 UNION SELECT * FROM "users" WHERE "id" = $3`,
+			[]interface{}{100, true, 5},
+		},
+		{
+			"Union_MergesCTEs",
+			func() (string, []interface{}, error) {
+				recent := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("orders").Select("user_id").Where("total", ">", 100)
+				banned := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("bans").Select("user_id")
+				member := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					With("banned", banned).
+					With("recent", recent).
+					Table("banned")
+				return api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					With("recent", recent).
+					Table("recent").
+					Union(member).
+					Build()
+			},
+			`WITH "banned" AS (SELECT "user_id" FROM "bans"), "recent" AS (SELECT "user_id" FROM "orders" WHERE "total" > $1) SELECT * FROM "banned" UNION SELECT * FROM "recent"`,
+			[]interface{}{100},
+		},
+		{
+			"WithMaterialized_MySQL_IgnoresHint",
+			func() (string, []interface{}, error) {
+				recent := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("orders")
+				return api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					WithMaterialized("recent", recent).
+					Table("recent").
+					Build()
+			},
+			"WITH `recent` AS (SELECT * FROM `orders`) SELECT * FROM `recent`",
+			nil,
+		},
+		{
+			"Update_With",
+			func() (string, []interface{}, error) {
+				recent := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("orders").Select("user_id").Where("total", ">", 100)
+				return api.NewUpdateQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					With("recent", recent).
+					Table("users").
+					Where("id", "=", 5).
+					Update(map[string]interface{}{"vip": true}).
+					Build()
+			},
+			`WITH "recent" AS (SELECT "user_id" FROM "orders" WHERE "total" > $1) UPDATE "users" SET "vip" = $2 WHERE "id" = $3`,
+			[]interface{}{100, true, 5},
+		},
+		{
+			"Delete_With",
+			func() (string, []interface{}, error) {
+				expired := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("sessions").Select("id").Where("age", ">", 30)
+				return api.NewDeleteQueryBuilder(mysql.NewMySQLQueryBuilder()).
+					With("expired", expired).
+					Table("sessions").
+					Where("user_id", "=", 5).
+					Build()
+			},
+			"WITH `expired` AS (SELECT `id` FROM `sessions` WHERE `age` > ?) DELETE FROM `sessions` WHERE `user_id` = ?",
+			[]interface{}{30, 5},
+		},
+		{
+			"InsertUsing_With",
+			func() (string, []interface{}, error) {
+				old := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("users").Where("age", ">", 90)
+				source := api.NewSelectQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).Table("old").Select("id").Where("id", ">", 3)
+				return api.NewInsertQueryBuilder(postgres.NewPostgreSQLQueryBuilder()).
+					Table("archive").
+					With("old", old).
+					InsertUsing([]string{"id"}, source).
+					Build()
+			},
+			`INSERT INTO "archive" ("id") WITH "old" AS (SELECT * FROM "users" WHERE "age" > $1) SELECT "id" FROM "old" WHERE "id" > $2`,
+			[]interface{}{90, 3},
+		},
+		{
+			"InsertUsing_With_SQLServer",
+			func() (string, []interface{}, error) {
+				old := api.NewSelectQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("users").Where("age", ">", 90)
+				source := api.NewSelectQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).Table("old").Select("id").Where("id", ">", 3)
+				return api.NewInsertQueryBuilder(sqlserver.NewSQLServerQueryBuilder()).
+					Table("archive").
+					With("old", old).
+					InsertUsing([]string{"id"}, source).
+					Build()
+			},
+			"WITH [old] AS (SELECT * FROM [users] WHERE [age] > @p1) INSERT INTO [archive] ([id]) SELECT [id] FROM [old] WHERE [id] > @p2",
+			[]interface{}{90, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+
+			if len(values) != len(tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+
+			for i := range values {
+				if values[i] != tt.expectedValues[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expectedValues[i], i, values[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWithApiBuilderDuplicateCTE(t *testing.T) {
+	member := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		With("recent", api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("orders")).
+		Table("recent")
+	_, _, err := api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		With("recent", api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("payments")).
+		Table("recent").
+		Union(member).
+		Build()
+	if !errors.Is(err, api.ErrDuplicateCTE) {
+		t.Errorf("expected ErrDuplicateCTE but got %v", err)
+	}
+}
+
+func TestWithApiInsertWithoutSelect(t *testing.T) {
+	_, _, err := api.NewInsertQueryBuilder(mysql.NewMySQLQueryBuilder()).
+		With("recent", api.NewSelectQueryBuilder(mysql.NewMySQLQueryBuilder()).Table("orders")).
+		Table("users").
+		Insert(map[string]interface{}{"name": "John"}).
+		Build()
+	if !errors.Is(err, api.ErrCTEWithoutSelect) {
+		t.Errorf("expected ErrCTEWithoutSelect but got %v", err)
+	}
+}
@@ -114,6 +114,25 @@ func BenchmarkSimpleInsert(b *testing.B) {
 	}
 }
 
+type benchUser struct {
+	ID   int64  `db:"id,pk"`
+	Name string `db:"name"`
+	Age  int    `db:"age"`
+}
+
+func BenchmarkInsertStruct(b *testing.B) {
+	dbStrategy := mysql.NewMySQLQueryBuilder()
+	user := &benchUser{Name: "John", Age: 30}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		api.NewInsertQueryBuilder(dbStrategy).
+			Table("users").
+			InsertStruct(user).
+			Build()
+	}
+}
+
 func BenchmarkInsertBatch(b *testing.B) {
 	dbStrategy := mysql.NewMySQLQueryBuilder()
 
@@ -0,0 +1,97 @@
+package db_test
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+)
+
+func TestPostgreSQLCopy(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 30, 0, 500000000, time.UTC)
+	rows := []map[string]interface{}{
+		{"id": 1, "name": "tab\there", "data": []byte{0xde, 0xad}, "at": at},
+		{"id": 2, "name": "a \\ b\nc", "meta": map[string]interface{}{"k": "v"}, "ok": true},
+		{"id": 3, "name": "", "meta": json.RawMessage(`[1,2]`), "score": math.Inf(1)},
+		{"id": 4, "name": sql.NullString{}, "ok": false, "score": 1.5},
+		{"id": 5, "name": `say "hi", bye`},
+	}
+
+	tests := []struct {
+		name          string
+		format        postgres.CopyFormat
+		expectedQuery string
+		expectedData  string
+	}{
+		{
+			"Text",
+			postgres.CopyText,
+			`COPY "events" ("at", "data", "id", "meta", "name", "ok", "score") FROM STDIN`,
+			"2024-05-01 12:30:00.5Z\t\\\\xdead\t1\t\\N\ttab\\there\t\\N\t\\N\n" +
+				"\\N\t\\N\t2\t{\"k\":\"v\"}\ta \\\\ b\\nc\tt\t\\N\n" +
+				"\\N\t\\N\t3\t[1,2]\t\t\\N\tInfinity\n" +
+				"\\N\t\\N\t4\t\\N\t\\N\tf\t1.5\n" +
+				"\\N\t\\N\t5\t\\N\tsay \"hi\", bye\t\\N\t\\N\n",
+		},
+		{
+			"CSV",
+			postgres.CopyCSV,
+			`COPY "events" ("at", "data", "id", "meta", "name", "ok", "score") FROM STDIN WITH (FORMAT csv)`,
+			"2024-05-01 12:30:00.5Z,\\xdead,1,,tab\there,,\n" +
+				",,2,\"{\"\"k\"\":\"\"v\"\"}\",\"a \\ b\nc\",t,\n" +
+				",,3,\"[1,2]\",\"\",,Infinity\n" +
+				",,4,,,f,1.5\n" +
+				",,5,,\"say \"\"hi\"\", bye\",,\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := postgres.NewPostgreSQLQueryBuilder().CopyFromRows("events", rows, tt.format)
+			if c.Query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, c.Query)
+			}
+
+			var buf bytes.Buffer
+			enc := c.NewEncoder(&buf)
+			for _, row := range rows {
+				if err := enc.Encode(row); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			if buf.String() != tt.expectedData {
+				t.Errorf("expected %q but got %q", tt.expectedData, buf.String())
+			}
+
+			data, err := io.ReadAll(c.Reader(postgres.SliceRows(rows)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != tt.expectedData {
+				t.Errorf("expected %q from the reader but got %q", tt.expectedData, data)
+			}
+		})
+	}
+}
+
+func TestPostgreSQLCopyErrors(t *testing.T) {
+	c := postgres.NewPostgreSQLQueryBuilder().CopyFrom("events", []string{"at"}, postgres.CopyText)
+
+	err := c.NewEncoder(io.Discard).Encode(map[string]interface{}{"at": api.Raw("now()")})
+	if !errors.Is(err, postgres.ErrCopyExpression) {
+		t.Errorf("expected ErrCopyExpression but got %v", err)
+	}
+
+	failed := errors.New("source failed")
+	_, err = io.ReadAll(c.Reader(func() (map[string]interface{}, error) { return nil, failed }))
+	if !errors.Is(err, failed) {
+		t.Errorf("expected the error of the source but got %v", err)
+	}
+}
@@ -0,0 +1,467 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/database/sqlite"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+func TestSQLiteQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		input    structs.Query
+		expected QueryBuilderExpected
+	}{
+		{
+			"Select",
+			"Select",
+			structs.Query{
+				Columns: &[]structs.Column{
+					{Name: "users.id"},
+					{Name: "users.name AS user_name"},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: `SELECT "users"."id", "users"."name" as "user_name"`,
+				Values:   nil,
+			},
+		},
+		{
+			"From",
+			"From",
+			structs.Query{
+				Table: structs.Table{Name: "users AS u"},
+			},
+			QueryBuilderExpected{
+				Expected: `FROM "users" as "u"`,
+				Values:   nil,
+			},
+		},
+		{
+			"Join",
+			"Join",
+			structs.Query{
+				Joins: &structs.Joins{
+					Joins: &[]structs.Join{
+						{
+							TargetNameMap:      map[string]string{consts.Join_LEFT: "profiles"},
+							SearchColumn:       "users.id",
+							SearchCondition:    "=",
+							SearchTargetColumn: "profiles.user_id",
+						},
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` LEFT JOIN "profiles" ON "users"."id" = "profiles"."user_id"`,
+				Values:   nil,
+			},
+		},
+		{
+			"Where",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "age", Condition: ">", Value: []interface{}{18}, Operator: consts.LogicalOperator_AND},
+							{Column: "name", Condition: "=", Value: []interface{}{"John"}, Operator: consts.LogicalOperator_OR},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE "age" > ? OR "name" = ?`,
+				Values:   []interface{}{18, "John"},
+			},
+		},
+		{
+			"WhereFullText",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								FullText: &structs.FullText{
+									Columns: []string{"name", "description"},
+									Search:  "search",
+								},
+								Operator: consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE ("name" MATCH ? OR "description" MATCH ?)`,
+				Values:   []interface{}{"search", "search"},
+			},
+		},
+		{
+			"WhereJsonContains",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								Column:       "options->languages",
+								JsonContains: &structs.JsonContains{Values: []interface{}{"en"}},
+								Operator:     consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE EXISTS (SELECT 1 FROM json_each(json_extract("options", '$.languages')) WHERE json_each.value = ?)`,
+				Values:   []interface{}{"en"},
+			},
+		},
+		{
+			"WhereJsonContains_MultipleValues",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								Column:       "tags",
+								JsonContains: &structs.JsonContains{Values: []interface{}{"go", "sql"}},
+								Operator:     consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE (EXISTS (SELECT 1 FROM json_each(json_extract("tags", '$')) WHERE json_each.value = ?) AND EXISTS (SELECT 1 FROM json_each(json_extract("tags", '$')) WHERE json_each.value = ?))`,
+				Values:   []interface{}{"go", "sql"},
+			},
+		},
+		{
+			"WhereJsonLength",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								Column:     "options->languages",
+								JsonLength: &structs.JsonLength{Operator: ">", Value: 1},
+								Operator:   consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE json_array_length("options", '$.languages') > ?`,
+				Values:   []interface{}{1},
+			},
+		},
+		{
+			"WhereDate",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "created_at", Function: "DATE", Condition: "=", Value: []interface{}{"2021-01-01"}, Operator: consts.LogicalOperator_AND},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE date("created_at") = ?`,
+				Values:   []interface{}{"2021-01-01"},
+			},
+		},
+		{
+			"WhereYear_And_Month",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "created_at", Function: "YEAR", Condition: "=", Value: []interface{}{"2021"}, Operator: consts.LogicalOperator_AND},
+							{Column: "created_at", Function: "MONTH", Condition: "=", Value: []interface{}{"1"}, Operator: consts.LogicalOperator_AND},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE CAST(strftime('%Y', "created_at") AS INTEGER) = ? AND CAST(strftime('%m', "created_at") AS INTEGER) = ?`,
+				Values:   []interface{}{"2021", "1"},
+			},
+		},
+		{
+			"WhereTime",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "created_at", Function: "TIME", Condition: ">", Value: []interface{}{"12:00:00"}, Operator: consts.LogicalOperator_AND},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` WHERE time("created_at") > ?`,
+				Values:   []interface{}{"12:00:00"},
+			},
+		},
+		{
+			"OrderBy",
+			"OrderBy",
+			structs.Query{
+				Order: &[]structs.Order{
+					{Column: "name", IsAsc: true},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: ` ORDER BY "name" ASC`,
+				Values:   nil,
+			},
+		},
+		{
+			"Limit_And_Offset",
+			"Limit_And_Offset",
+			structs.Query{
+				Limit:  structs.Limit{Limit: 10},
+				Offset: structs.Offset{Offset: 5},
+			},
+			QueryBuilderExpected{
+				Expected: " LIMIT 10 OFFSET 5",
+				Values:   nil,
+			},
+		},
+		{
+			"Lock",
+			"Lock",
+			structs.Query{
+				Lock: &structs.Lock{LockType: consts.Lock_FOR_UPDATE},
+			},
+			QueryBuilderExpected{
+				Expected: "",
+				Values:   nil,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			builder := sqlite.NewSQLiteQueryBuilder()
+			sb := make([]byte, 0, consts.StringBuffer_Middle_Query_Grow)
+
+			var got string
+			var gotValues []interface{} = nil
+			switch tt.method {
+			case "Select":
+				values, _ := builder.Select(&sb, tt.input.Columns, "", nil)
+				got = "SELECT " + string(sb)
+				gotValues = values
+			case "From":
+				builder.From(&sb, tt.input.Table.Name)
+				got = string(sb)
+			case "Where":
+				values, _ := builder.Where(&sb, tt.input.ConditionGroups)
+				got = string(sb)
+				gotValues = values
+			case "Join":
+				values := builder.Join(&sb, tt.input.Joins)
+				got = string(sb)
+				gotValues = values
+			case "OrderBy":
+				builder.OrderBy(&sb, tt.input.Order)
+				got = string(sb)
+			case "Limit_And_Offset":
+				builder.Limit(&sb, tt.input.Limit)
+				builder.Offset(&sb, tt.input.Offset)
+				got = string(sb)
+			case "Lock":
+				builder.Lock(&sb, tt.input.Lock)
+				got = string(sb)
+			}
+			if got != tt.expected.Expected {
+				t.Errorf("expected '%s' but got '%s'", tt.expected.Expected, got)
+			}
+
+			if len(gotValues) != len(tt.expected.Values) {
+				t.Errorf("expected '%v' but got '%v'", tt.expected.Values, gotValues)
+			}
+			for i := range gotValues {
+				if gotValues[i] != tt.expected.Values[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expected.Values[i], i, gotValues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSQLiteQueryBuilder_BuildOffsetWithoutLimit(t *testing.T) {
+	t.Parallel()
+
+	builder := sqlite.NewSQLiteQueryBuilder()
+	sb := make([]byte, 0, consts.StringBuffer_Middle_Query_Grow)
+
+	_, err := builder.Build(&sb, &structs.Query{
+		Table:   structs.Table{Name: "users"},
+		Columns: &[]structs.Column{},
+		Joins:   &structs.Joins{},
+		Order:   &[]structs.Order{},
+		Group:   &structs.GroupBy{},
+		Offset:  structs.Offset{Offset: 20},
+		Lock:    &structs.Lock{LockType: consts.Lock_FOR_UPDATE},
+	}, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `SELECT * FROM "users" LIMIT -1 OFFSET 20`
+	if got := string(sb); got != want {
+		t.Fatalf("expected %q but got %q", want, got)
+	}
+}
+
+func TestSQLiteInsertQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		input    *structs.InsertQuery
+		expected QueryBuilderExpected
+	}{
+		{
+			"Insert",
+			"Insert",
+			&structs.InsertQuery{
+				Table:  "users",
+				Values: map[string]interface{}{"name": "John", "age": 30},
+			},
+			QueryBuilderExpected{
+				Expected: `INSERT INTO "users" ("age", "name") VALUES (?, ?)`,
+				Values:   []interface{}{30, "John"},
+			},
+		},
+		{
+			"InsertIgnore",
+			"InsertIgnore",
+			&structs.InsertQuery{
+				Table:  "users",
+				Values: map[string]interface{}{"name": "John", "age": 30},
+				Ignore: true,
+			},
+			QueryBuilderExpected{
+				Expected: `INSERT OR IGNORE INTO "users" ("age", "name") VALUES (?, ?)`,
+				Values:   []interface{}{30, "John"},
+			},
+		},
+		{
+			"InsertOrIgnoreBatch",
+			"BuildInsert",
+			&structs.InsertQuery{
+				Table:       "users",
+				ValuesBatch: []map[string]interface{}{{"name": "John"}, {"name": "Jane"}},
+				Ignore:      true,
+			},
+			QueryBuilderExpected{
+				Expected: `INSERT OR IGNORE INTO "users" ("name") VALUES (?), (?)`,
+				Values:   []interface{}{"John", "Jane"},
+			},
+		},
+		{
+			"Upsert",
+			"Upsert",
+			&structs.InsertQuery{
+				Table:       "flights",
+				ValuesBatch: []map[string]interface{}{{"departure": "Oakland", "destination": "San Diego", "price": 99}},
+				Upsert:      &structs.Upsert{UniqueColumns: []string{"departure", "destination"}, UpdateColumns: []string{"price"}},
+			},
+			QueryBuilderExpected{
+				Expected: `INSERT INTO "flights" ("departure", "destination", "price") VALUES (?, ?, ?) ON CONFLICT ("departure", "destination") DO UPDATE SET "price" = EXCLUDED."price"`,
+				Values:   []interface{}{"Oakland", "San Diego", 99},
+			},
+		},
+	}
+
+	builder := sqlite.NewSQLiteQueryBuilder()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+			var gotValues []interface{} = nil
+			switch tt.method {
+			case "Insert":
+				got, gotValues, _ = builder.Insert(tt.input)
+			case "InsertIgnore":
+				got, gotValues, _ = builder.InsertIgnore(tt.input)
+			case "BuildInsert":
+				got, gotValues, _ = builder.BuildInsert(tt.input)
+			case "Upsert":
+				got, gotValues, _ = builder.Upsert(tt.input)
+			}
+			if got != tt.expected.Expected {
+				t.Errorf("expected '%s' but got '%s'", tt.expected.Expected, got)
+			}
+
+			if len(gotValues) != len(tt.expected.Values) {
+				t.Errorf("expected '%v' but got '%v'", tt.expected.Values, gotValues)
+			}
+		})
+	}
+}
+
+func TestSQLiteUpdateQueryBuilder(t *testing.T) {
+	t.Parallel()
+
+	builder := sqlite.NewSQLiteQueryBuilder()
+	got, values, err := builder.BuildUpdate(&structs.UpdateQuery{
+		Table:  "users",
+		Values: map[string]interface{}{"options->language": "en", "name": "John"},
+		Query: &structs.Query{
+			ConditionGroups: []structs.WhereGroup{
+				{
+					Conditions:   []structs.Where{{Column: "id", Condition: "=", Value: []interface{}{1}}},
+					IsDummyGroup: true,
+				},
+			},
+			Joins: &structs.Joins{},
+			Order: &[]structs.Order{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := `UPDATE "users" SET "name" = ?, "options" = json_set("options", '$.language', ?) WHERE "id" = ?`
+	if got != want {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+	if len(values) != 3 {
+		t.Errorf("expected 3 values but got %v", values)
+	}
+}
@@ -0,0 +1,428 @@
+package db_test
+
+import (
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/database/sqlserver"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/consts"
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+)
+
+func TestSQLServerQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		input    structs.Query
+		expected QueryBuilderExpected
+	}{
+		{
+			"Select",
+			"Select",
+			structs.Query{
+				Columns: &[]structs.Column{
+					{Name: "dbo.users.id"},
+					{Name: "users.name AS user_name"},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: "SELECT [dbo].[users].[id], [users].[name] as [user_name]",
+				Values:   nil,
+			},
+		},
+		{
+			"From_EscapesClosingBracket",
+			"From",
+			structs.Query{
+				Table: structs.Table{Name: "odd]name"},
+			},
+			QueryBuilderExpected{
+				Expected: "FROM [odd]]name]",
+				Values:   nil,
+			},
+		},
+		{
+			"From_BracketQuotedInput",
+			"From",
+			structs.Query{
+				Table: structs.Table{Name: "[dbo].[users] AS u"},
+			},
+			QueryBuilderExpected{
+				Expected: "FROM [dbo].[users] as [u]",
+				Values:   nil,
+			},
+		},
+		{
+			"Where",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "age", Condition: ">", Value: []interface{}{18}, Operator: consts.LogicalOperator_AND},
+							{Column: "name", Condition: "=", Value: []interface{}{"John"}, Operator: consts.LogicalOperator_AND},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE [age] > @p1 AND [name] = @p2",
+				Values:   []interface{}{18, "John"},
+			},
+		},
+		{
+			"WhereFullText",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								FullText: &structs.FullText{Columns: []string{"name", "description"}, Search: "search"},
+								Operator: consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE FREETEXT(([name], [description]), @p1)",
+				Values:   []interface{}{"search"},
+			},
+		},
+		{
+			"WhereFullText_Boolean",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								FullText: &structs.FullText{
+									Columns: []string{"name"},
+									Search:  `"search*"`,
+									Options: map[string]interface{}{"mode": "boolean"},
+								},
+								Operator: consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE CONTAINS(([name]), @p1)",
+				Values:   []interface{}{`"search*"`},
+			},
+		},
+		{
+			"WhereJsonContains",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								Column:       "options->languages",
+								JsonContains: &structs.JsonContains{Values: []interface{}{"en"}},
+								Operator:     consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE EXISTS (SELECT 1 FROM OPENJSON([options], '$.languages') WHERE [value] = @p1)",
+				Values:   []interface{}{"en"},
+			},
+		},
+		{
+			"WhereJsonLength",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{
+								Column:     "options->languages",
+								JsonLength: &structs.JsonLength{Operator: ">", Value: 1},
+								Operator:   consts.LogicalOperator_AND,
+							},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE (SELECT COUNT(*) FROM OPENJSON([options], '$.languages')) > @p1",
+				Values:   []interface{}{1},
+			},
+		},
+		{
+			"WhereDate_And_Year",
+			"Where",
+			structs.Query{
+				ConditionGroups: []structs.WhereGroup{
+					{
+						Conditions: []structs.Where{
+							{Column: "created_at", Function: "DATE", Condition: "=", Value: []interface{}{"2021-01-01"}, Operator: consts.LogicalOperator_AND},
+							{Column: "created_at", Function: "YEAR", Condition: "=", Value: []interface{}{2021}, Operator: consts.LogicalOperator_AND},
+						},
+						IsDummyGroup: true,
+						Operator:     consts.LogicalOperator_AND,
+					},
+				},
+			},
+			QueryBuilderExpected{
+				Expected: " WHERE CAST([created_at] AS DATE) = @p1 AND YEAR([created_at]) = @p2",
+				Values:   []interface{}{"2021-01-01", 2021},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			builder := sqlserver.NewSQLServerQueryBuilder()
+			sb := make([]byte, 0, consts.StringBuffer_Middle_Query_Grow)
+
+			var got string
+			var gotValues []interface{} = nil
+			switch tt.method {
+			case "Select":
+				values, _ := builder.Select(&sb, tt.input.Columns, "", nil)
+				got = "SELECT " + string(sb)
+				gotValues = values
+			case "From":
+				builder.From(&sb, tt.input.Table.Name)
+				got = string(sb)
+			case "Where":
+				values, _ := builder.Where(&sb, tt.input.ConditionGroups)
+				got = string(sb)
+				gotValues = values
+			}
+			if got != tt.expected.Expected {
+				t.Errorf("expected '%s' but got '%s'", tt.expected.Expected, got)
+			}
+
+			if len(gotValues) != len(tt.expected.Values) {
+				t.Errorf("expected '%v' but got '%v'", tt.expected.Values, gotValues)
+			}
+			for i := range gotValues {
+				if gotValues[i] != tt.expected.Values[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expected.Values[i], i, gotValues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSQLServerQueryBuilder_Build(t *testing.T) {
+	newQuery := func() structs.Query {
+		return structs.Query{
+			Table:   structs.Table{Name: "users"},
+			Columns: &[]structs.Column{},
+			Joins:   &structs.Joins{},
+			Order:   &[]structs.Order{},
+			Group:   &structs.GroupBy{},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		setup    func(q *structs.Query)
+		expected string
+	}{
+		{
+			"Top",
+			func(q *structs.Query) {
+				q.Limit = structs.Limit{Limit: 10}
+			},
+			"SELECT TOP (10) * FROM [users]",
+		},
+		{
+			"Top_With_Distinct",
+			func(q *structs.Query) {
+				q.Columns = &[]structs.Column{{Name: "name", Distinct: true}}
+				q.Limit = structs.Limit{Limit: 5}
+			},
+			"SELECT DISTINCT TOP (5) [name] FROM [users]",
+		},
+		{
+			"Offset_Fetch",
+			func(q *structs.Query) {
+				q.Order = &[]structs.Order{{Column: "id", IsAsc: true}}
+				q.Limit = structs.Limit{Limit: 10}
+				q.Offset = structs.Offset{Offset: 20}
+			},
+			"SELECT * FROM [users] ORDER BY [id] ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY",
+		},
+		{
+			"Offset_Without_Order",
+			func(q *structs.Query) {
+				q.Offset = structs.Offset{Offset: 20}
+			},
+			"SELECT * FROM [users] ORDER BY (SELECT NULL) OFFSET 20 ROWS",
+		},
+		{
+			"LockForUpdate",
+			func(q *structs.Query) {
+				q.Lock = &structs.Lock{LockType: consts.Lock_FOR_UPDATE}
+				q.ConditionGroups = []structs.WhereGroup{
+					{
+						Conditions:   []structs.Where{{Column: "id", Condition: "=", Value: []interface{}{1}}},
+						IsDummyGroup: true,
+					},
+				}
+			},
+			"SELECT * FROM [users] WITH (UPDLOCK, ROWLOCK) WHERE [id] = @p1",
+		},
+		{
+			"SharedLock",
+			func(q *structs.Query) {
+				q.Lock = &structs.Lock{LockType: consts.Lock_SHARE_MODE}
+			},
+			"SELECT * FROM [users] WITH (HOLDLOCK)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := sqlserver.NewSQLServerQueryBuilder()
+			sb := make([]byte, 0, consts.StringBuffer_Middle_Query_Grow)
+			q := newQuery()
+			tt.setup(&q)
+
+			if _, err := builder.Build(&sb, &q, 0, nil); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := string(sb); got != tt.expected {
+				t.Errorf("expected '%s' but got '%s'", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestSQLServerInsertQueryBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		input    *structs.InsertQuery
+		expected QueryBuilderExpected
+		wantErr  bool
+	}{
+		{
+			"Insert",
+			"Insert",
+			&structs.InsertQuery{
+				Table:  "users",
+				Values: map[string]interface{}{"name": "John", "age": 30},
+			},
+			QueryBuilderExpected{
+				Expected: "INSERT INTO [users] ([age], [name]) VALUES (@p1, @p2)",
+				Values:   []interface{}{30, "John"},
+			},
+			false,
+		},
+		{
+			"Upsert",
+			"Upsert",
+			&structs.InsertQuery{
+				Table:       "flights",
+				ValuesBatch: []map[string]interface{}{{"departure": "Oakland", "destination": "San Diego", "price": 99}},
+				Upsert:      &structs.Upsert{UniqueColumns: []string{"departure", "destination"}, UpdateColumns: []string{"price"}},
+			},
+			QueryBuilderExpected{
+				Expected: "MERGE INTO [flights] AS [target] USING (VALUES (@p1, @p2, @p3)) AS [source] ([departure], [destination], [price]) " +
+					"ON [target].[departure] = [source].[departure] AND [target].[destination] = [source].[destination] " +
+					"WHEN MATCHED THEN UPDATE SET [target].[price] = [source].[price] " +
+					"WHEN NOT MATCHED THEN INSERT ([departure], [destination], [price]) VALUES ([source].[departure], [source].[destination], [source].[price]);",
+				Values: []interface{}{"Oakland", "San Diego", 99},
+			},
+			false,
+		},
+		{
+			"InsertIgnore",
+			"BuildInsert",
+			&structs.InsertQuery{
+				Table:  "users",
+				Values: map[string]interface{}{"name": "John"},
+				Ignore: true,
+			},
+			QueryBuilderExpected{},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := sqlserver.NewSQLServerQueryBuilder()
+
+			var got string
+			var gotValues []interface{} = nil
+			var err error
+			switch tt.method {
+			case "Insert":
+				got, gotValues, err = builder.Insert(tt.input)
+			case "BuildInsert":
+				got, gotValues, err = builder.BuildInsert(tt.input)
+			case "Upsert":
+				got, gotValues, err = builder.Upsert(tt.input)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error state: %v", err)
+			}
+			if got != tt.expected.Expected {
+				t.Errorf("expected '%s' but got '%s'", tt.expected.Expected, got)
+			}
+
+			if len(gotValues) != len(tt.expected.Values) {
+				t.Errorf("expected '%v' but got '%v'", tt.expected.Values, gotValues)
+			}
+			for i := range gotValues {
+				if gotValues[i] != tt.expected.Values[i] {
+					t.Errorf("expected value %v at index %d but got %v", tt.expected.Values[i], i, gotValues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSQLServerUpdateQueryBuilder(t *testing.T) {
+	t.Parallel()
+
+	builder := sqlserver.NewSQLServerQueryBuilder()
+	got, _, err := builder.BuildUpdate(&structs.UpdateQuery{
+		Table:  "users",
+		Values: map[string]interface{}{"options->language": "en"},
+		Query: &structs.Query{
+			ConditionGroups: []structs.WhereGroup{
+				{
+					Conditions:   []structs.Where{{Column: "id", Condition: "=", Value: []interface{}{1}}},
+					IsDummyGroup: true,
+				},
+			},
+			Joins: &structs.Joins{},
+			Order: &[]structs.Order{},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := "UPDATE [users] SET [options] = JSON_MODIFY([options], '$.language', @p1) WHERE [id] = @p2"
+	if got != want {
+		t.Errorf("expected %q but got %q", want, got)
+	}
+}
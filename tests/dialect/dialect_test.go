@@ -0,0 +1,126 @@
+package dialect_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/dialect"
+)
+
+// tidbUtils and TiDBQueryBuilder compose a dialect the way an external module
+// would, using only the dialect package.
+type tidbUtils struct{}
+
+func (tidbUtils) GetPlaceholder() string { return "?" }
+
+func (tidbUtils) EscapeRelation(sb []byte, value string) []byte {
+	return dialect.AppendEscapedRelation(sb, value, '`')
+}
+
+func (tidbUtils) EscapeReference(sb []byte, value string) []byte {
+	return dialect.AppendEscapedReference(sb, value, '`')
+}
+
+func (tidbUtils) EscapeAliasedValue(sb []byte, value string) []byte {
+	return dialect.AppendEscapedAliasedValue(sb, value, '`')
+}
+
+func (u tidbUtils) GetQueryBuilderStrategy() dialect.QueryBuilderStrategy {
+	return newTiDBQueryBuilder()
+}
+
+func (tidbUtils) Dialect() string { return "tidb" }
+
+type TiDBQueryBuilder struct {
+	dialect.BaseQueryBuilder
+}
+
+func newTiDBQueryBuilder() *TiDBQueryBuilder {
+	return &TiDBQueryBuilder{BaseQueryBuilder: *dialect.NewBaseQueryBuilder(tidbUtils{})}
+}
+
+// Build puts an optimizer hint in front of the base rendering.
+func (m TiDBQueryBuilder) Build(sb *[]byte, q *dialect.Query, number int, unions *[]dialect.Union) ([]interface{}, error) {
+	*sb = append(*sb, "/*+ READ_FROM_STORAGE(TIFLASH) */ "...)
+	return m.BaseQueryBuilder.Build(sb, q, number, unions)
+}
+
+func init() {
+	dialect.Register("tidb", func() dialect.QueryBuilderStrategy { return newTiDBQueryBuilder() })
+}
+
+func TestCustomDialect(t *testing.T) {
+	strategy, err := dialect.New("tidb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		build          func() (string, []interface{}, error)
+		expectedQuery  string
+		expectedValues []interface{}
+	}{
+		{
+			"Select",
+			func() (string, []interface{}, error) {
+				return api.NewSelectQueryBuilder(strategy).Table("users").Where("users.age", ">", 18).OrderBy("name", "ASC").Build()
+			},
+			"/*+ READ_FROM_STORAGE(TIFLASH) */ SELECT * FROM `users` WHERE `users`.`age` > ? ORDER BY `name` ASC",
+			[]interface{}{18},
+		},
+		{
+			"Update",
+			func() (string, []interface{}, error) {
+				return api.NewUpdateQueryBuilder(strategy).Table("users").Where("id", "=", 1).Update(map[string]interface{}{"name": "Jane"}).Build()
+			},
+			"UPDATE `users` SET `name` = ? WHERE `id` = ?",
+			[]interface{}{"Jane", 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, values, err := tt.build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != tt.expectedQuery {
+				t.Errorf("expected '%s' but got '%s'", tt.expectedQuery, query)
+			}
+			if !reflect.DeepEqual(values, tt.expectedValues) {
+				t.Errorf("expected values %v but got %v", tt.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	expected := []string{dialect.MySQL, dialect.PostgreSQL, dialect.SQLite, dialect.SQLServer, "tidb"}
+	if names := dialect.Names(); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected %v but got %v", expected, names)
+	}
+
+	for _, name := range expected {
+		strategy, err := dialect.New(name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strategy.Dialect() != name {
+			t.Errorf("expected dialect %s but got %s", name, strategy.Dialect())
+		}
+	}
+
+	if _, err := dialect.New("oracle"); !errors.Is(err, dialect.ErrUnknownDialect) {
+		t.Errorf("expected %v but got %v", dialect.ErrUnknownDialect, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a duplicate dialect")
+		}
+	}()
+	dialect.Register(dialect.MySQL, func() dialect.QueryBuilderStrategy { return newTiDBQueryBuilder() })
+}
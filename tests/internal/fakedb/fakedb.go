@@ -0,0 +1,215 @@
+// Package fakedb is a database/sql driver that records statements and replies
+// with queued rows, so the execution layer can be tested without a server.
+package fakedb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+const DriverName = "fakedb"
+
+// Call is a statement received by the driver.
+type Call struct {
+	Query string
+	Args  []interface{}
+}
+
+// Recorder holds the calls and the queued replies of one fake database.
+type Recorder struct {
+	mu           sync.Mutex
+	calls        []Call
+	rows         []*rowSet
+	errs         []error
+	lastInsertID int64
+	rowsAffected int64
+}
+
+type rowSet struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+var (
+	recorders sync.Map
+	sequence  atomic.Int64
+)
+
+func init() {
+	sql.Register(DriverName, fakeDriver{})
+}
+
+// Open returns a database backed by a new Recorder.
+func Open() (*sql.DB, *Recorder) {
+	dsn := fmt.Sprintf("fakedb-%d", sequence.Add(1))
+	r := &Recorder{}
+	recorders.Store(dsn, r)
+
+	db, err := sql.Open(DriverName, dsn)
+	if err != nil {
+		panic(err)
+	}
+	return db, r
+}
+
+// AddRows queues the rows returned by the next query.
+func (r *Recorder) AddRows(columns []string, rows ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rows = append(r.rows, &rowSet{columns: columns, rows: rows})
+}
+
+// FailNext makes the next statement fail with err.
+func (r *Recorder) FailNext(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+// SetResult sets the result of every following Exec.
+func (r *Recorder) SetResult(lastInsertID, rowsAffected int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastInsertID = lastInsertID
+	r.rowsAffected = rowsAffected
+}
+
+// Calls returns the statements received so far, including BEGIN, COMMIT and
+// ROLLBACK for transactions.
+func (r *Recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
+
+// Queries returns the SQL of the statements received so far.
+func (r *Recorder) Queries() []string {
+	calls := r.Calls()
+	queries := make([]string, len(calls))
+	for i, c := range calls {
+		queries[i] = c.Query
+	}
+	return queries
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	values := make([]interface{}, len(args))
+	for i, a := range args {
+		values[i] = a.Value
+	}
+	r.calls = append(r.calls, Call{Query: query, Args: values})
+
+	if len(r.errs) > 0 {
+		err := r.errs[0]
+		r.errs = r.errs[1:]
+		return err
+	}
+	return nil
+}
+
+func (r *Recorder) nextRows() *rowSet {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.rows) == 0 {
+		return &rowSet{}
+	}
+	rs := r.rows[0]
+	r.rows = r.rows[1:]
+	return rs
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	r, ok := recorders.Load(dsn)
+	if !ok {
+		return nil, fmt.Errorf("fakedb: unknown dsn %q", dsn)
+	}
+	return &conn{r: r.(*Recorder)}, nil
+}
+
+type conn struct {
+	r *Recorder
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fakedb: prepared statements are not supported")
+}
+
+func (c *conn) Close() error { return nil }
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if err := c.r.record("BEGIN", nil); err != nil {
+		return nil, err
+	}
+	return &tx{r: c.r}, nil
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	// like a real driver, a statement is not sent once ctx is done
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.r.record(query, args); err != nil {
+		return nil, err
+	}
+	return &rows{set: c.r.nextRows()}, nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := c.r.record(query, args); err != nil {
+		return nil, err
+	}
+	c.r.mu.Lock()
+	defer c.r.mu.Unlock()
+	return result{lastInsertID: c.r.lastInsertID, rowsAffected: c.r.rowsAffected}, nil
+}
+
+type tx struct {
+	r *Recorder
+}
+
+func (t *tx) Commit() error   { return t.r.record("COMMIT", nil) }
+func (t *tx) Rollback() error { return t.r.record("ROLLBACK", nil) }
+
+type result struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r result) LastInsertId() (int64, error) { return r.lastInsertID, nil }
+func (r result) RowsAffected() (int64, error) { return r.rowsAffected, nil }
+
+type rows struct {
+	set *rowSet
+	pos int
+}
+
+func (r *rows) Columns() []string { return r.set.columns }
+func (r *rows) Close() error      { return nil }
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.set.rows) {
+		return io.EOF
+	}
+	copy(dest, r.set.rows[r.pos])
+	r.pos++
+	return nil
+}
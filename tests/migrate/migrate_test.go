@@ -0,0 +1,350 @@
+package migrate_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/faciam-dev/goquent-query-builder/api"
+	"github.com/faciam-dev/goquent-query-builder/database/mysql"
+	"github.com/faciam-dev/goquent-query-builder/database/postgres"
+	"github.com/faciam-dev/goquent-query-builder/migrate"
+	"github.com/faciam-dev/goquent-query-builder/tests/internal/fakedb"
+)
+
+var createUsers = migrate.Migration{
+	Version: "20240101000000_create_users",
+	Up: func(ctx context.Context, tx *migrate.Tx) error {
+		return tx.Schema(ctx, func(s *api.SchemaBuilder) {
+			s.CreateTable("users", func(t *api.Blueprint) {
+				t.Increments("id")
+				t.String("name")
+			})
+		})
+	},
+	Down: func(ctx context.Context, tx *migrate.Tx) error {
+		return tx.Schema(ctx, func(s *api.SchemaBuilder) {
+			s.DropTable("users")
+		})
+	},
+}
+
+var addEmail = migrate.Migration{
+	Version: "20240102000000_add_email",
+	Up: func(ctx context.Context, tx *migrate.Tx) error {
+		return tx.Exec(ctx, "ALTER TABLE users ADD COLUMN email VARCHAR(255) NULL")
+	},
+	Down: func(ctx context.Context, tx *migrate.Tx) error {
+		return tx.Exec(ctx, "ALTER TABLE users DROP COLUMN email")
+	},
+}
+
+func TestMigrate(t *testing.T) {
+	tests := []struct {
+		name            string
+		run             func(m *migrate.Migrator) ([]string, error)
+		rows            [][]driver.Value
+		expectedDone    []string
+		expectedQueries []string
+	}{
+		{
+			"Migrate",
+			func(m *migrate.Migrator) ([]string, error) {
+				return m.Migrate(context.Background())
+			},
+			nil,
+			[]string{createUsers.Version, addEmail.Version},
+			[]string{
+				"SELECT GET_LOCK(?, -1)",
+				"CREATE TABLE IF NOT EXISTS `migrations` (`version` VARCHAR(255) NOT NULL, `batch` BIGINT NOT NULL, PRIMARY KEY (`version`))",
+				"SELECT `version`, `batch` FROM `migrations`",
+				"BEGIN",
+				"CREATE TABLE `users` (`id` INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY, `name` VARCHAR(255) NOT NULL)",
+				"INSERT INTO `migrations` (`batch`, `version`) VALUES (?, ?)",
+				"COMMIT",
+				"BEGIN",
+				"ALTER TABLE users ADD COLUMN email VARCHAR(255) NULL",
+				"INSERT INTO `migrations` (`batch`, `version`) VALUES (?, ?)",
+				"COMMIT",
+				"SELECT RELEASE_LOCK(?)",
+			},
+		},
+		{
+			"MigratePending",
+			func(m *migrate.Migrator) ([]string, error) {
+				return m.Migrate(context.Background())
+			},
+			[][]driver.Value{{createUsers.Version, int64(1)}},
+			[]string{addEmail.Version},
+			[]string{
+				"SELECT GET_LOCK(?, -1)",
+				"CREATE TABLE IF NOT EXISTS `migrations` (`version` VARCHAR(255) NOT NULL, `batch` BIGINT NOT NULL, PRIMARY KEY (`version`))",
+				"SELECT `version`, `batch` FROM `migrations`",
+				"BEGIN",
+				"ALTER TABLE users ADD COLUMN email VARCHAR(255) NULL",
+				"INSERT INTO `migrations` (`batch`, `version`) VALUES (?, ?)",
+				"COMMIT",
+				"SELECT RELEASE_LOCK(?)",
+			},
+		},
+		{
+			"Rollback",
+			func(m *migrate.Migrator) ([]string, error) {
+				return m.Rollback(context.Background(), 1)
+			},
+			[][]driver.Value{{createUsers.Version, int64(1)}, {addEmail.Version, int64(2)}},
+			[]string{addEmail.Version},
+			[]string{
+				"SELECT GET_LOCK(?, -1)",
+				"CREATE TABLE IF NOT EXISTS `migrations` (`version` VARCHAR(255) NOT NULL, `batch` BIGINT NOT NULL, PRIMARY KEY (`version`))",
+				"SELECT `version`, `batch` FROM `migrations`",
+				"BEGIN",
+				"ALTER TABLE users DROP COLUMN email",
+				"DELETE FROM `migrations` WHERE `version` = ?",
+				"COMMIT",
+				"SELECT RELEASE_LOCK(?)",
+			},
+		},
+		{
+			"Reset",
+			func(m *migrate.Migrator) ([]string, error) {
+				return m.Reset(context.Background())
+			},
+			[][]driver.Value{{createUsers.Version, int64(1)}, {addEmail.Version, int64(1)}},
+			[]string{addEmail.Version, createUsers.Version},
+			[]string{
+				"SELECT GET_LOCK(?, -1)",
+				"CREATE TABLE IF NOT EXISTS `migrations` (`version` VARCHAR(255) NOT NULL, `batch` BIGINT NOT NULL, PRIMARY KEY (`version`))",
+				"SELECT `version`, `batch` FROM `migrations`",
+				"BEGIN",
+				"ALTER TABLE users DROP COLUMN email",
+				"DELETE FROM `migrations` WHERE `version` = ?",
+				"COMMIT",
+				"BEGIN",
+				"DROP TABLE `users`",
+				"DELETE FROM `migrations` WHERE `version` = ?",
+				"COMMIT",
+				"SELECT RELEASE_LOCK(?)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := fakedb.Open()
+			defer db.Close()
+			rec.AddRows([]string{"lock"}, []driver.Value{int64(1)})
+			rec.AddRows([]string{"version", "batch"}, tt.rows...)
+
+			done, err := tt.run(migrate.New(db, mysql.NewMySQLQueryBuilder()).Register(addEmail, createUsers))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(done, tt.expectedDone) {
+				t.Errorf("expected %v but got %v", tt.expectedDone, done)
+			}
+			if queries := rec.Queries(); !reflect.DeepEqual(queries, tt.expectedQueries) {
+				t.Errorf("expected %q but got %q", tt.expectedQueries, queries)
+			}
+		})
+	}
+}
+
+func TestMigrateAdvisoryLockPostgreSQL(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+
+	if _, err := migrate.New(db, postgres.NewPostgreSQLQueryBuilder()).Migrate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := rec.Calls()
+	if calls[0].Query != "SELECT pg_advisory_lock($1)" || calls[len(calls)-1].Query != "SELECT pg_advisory_unlock($1)" {
+		t.Errorf("unexpected lock statements: %q", rec.Queries())
+	}
+	if calls[0].Args[0] != calls[len(calls)-1].Args[0] {
+		t.Errorf("lock and unlock keys differ: %v and %v", calls[0].Args[0], calls[len(calls)-1].Args[0])
+	}
+}
+
+func TestMigrateUnlock(t *testing.T) {
+	t.Run("CancelledContext", func(t *testing.T) {
+		db, rec := fakedb.Open()
+		defer db.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		cancelling := migrate.Migration{
+			Version: "20240103000000_cancelling",
+			Up: func(ctx context.Context, tx *migrate.Tx) error {
+				cancel()
+				return nil
+			},
+		}
+
+		if _, err := migrate.New(db, postgres.NewPostgreSQLQueryBuilder()).Register(cancelling).Migrate(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled but got %v", err)
+		}
+
+		queries := rec.Queries()
+		if queries[len(queries)-1] != "SELECT pg_advisory_unlock($1)" {
+			t.Errorf("expected a released lock but got %q", queries)
+		}
+	})
+
+	t.Run("FailedUnlockDiscardsConnection", func(t *testing.T) {
+		db, rec := fakedb.Open()
+		defer db.Close()
+
+		errUnlock := errors.New("unlock failed")
+		failing := migrate.Migration{
+			Version: "20240103000000_failing_unlock",
+			Up: func(ctx context.Context, tx *migrate.Tx) error {
+				// the bookkeeping INSERT and the COMMIT succeed
+				rec.FailNext(nil)
+				rec.FailNext(nil)
+				rec.FailNext(errUnlock)
+				return nil
+			},
+		}
+
+		if _, err := migrate.New(db, postgres.NewPostgreSQLQueryBuilder()).Register(failing).Migrate(context.Background()); !errors.Is(err, errUnlock) {
+			t.Fatalf("expected the unlock error but got %v", err)
+		}
+		if n := db.Stats().OpenConnections; n != 0 {
+			t.Errorf("expected the connection to be discarded but %d are open", n)
+		}
+	})
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	var out bytes.Buffer
+	done, err := migrate.New(nil, postgres.NewPostgreSQLQueryBuilder()).
+		Register(createUsers, addEmail).
+		SetDryRun(&out).
+		Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(done) != 2 {
+		t.Errorf("expected 2 migrations but got %v", done)
+	}
+
+	expected := `-- 20240101000000_create_users: up
+CREATE TABLE "users" ("id" SERIAL PRIMARY KEY, "name" VARCHAR(255) NOT NULL);
+INSERT INTO "migrations" ("batch", "version") VALUES ($1, $2); -- [1 20240101000000_create_users]
+-- 20240102000000_add_email: up
+ALTER TABLE users ADD COLUMN email VARCHAR(255) NULL;
+INSERT INTO "migrations" ("batch", "version") VALUES ($1, $2); -- [1 20240102000000_add_email]
+`
+	if out.String() != expected {
+		t.Errorf("expected\n%s\nbut got\n%s", expected, out.String())
+	}
+}
+
+func TestMigrateStatus(t *testing.T) {
+	db, rec := fakedb.Open()
+	defer db.Close()
+	rec.AddRows([]string{"version", "batch"}, []driver.Value{createUsers.Version, int64(3)})
+
+	statuses, err := migrate.New(db, mysql.NewMySQLQueryBuilder()).Register(createUsers, addEmail).Status(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []migrate.Status{
+		{Version: createUsers.Version, Applied: true, Batch: 3},
+		{Version: addEmail.Version},
+	}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("expected %v but got %v", expected, statuses)
+	}
+}
+
+func TestMigrateErrors(t *testing.T) {
+	failing := migrate.Migration{
+		Version: "20240103000000_failing",
+		Up: func(ctx context.Context, tx *migrate.Tx) error {
+			return errors.New("boom")
+		},
+	}
+
+	t.Run("FailingMigrationRollsBack", func(t *testing.T) {
+		db, rec := fakedb.Open()
+		defer db.Close()
+		rec.AddRows([]string{"lock"}, []driver.Value{int64(1)})
+
+		_, err := migrate.New(db, mysql.NewMySQLQueryBuilder()).Register(failing).Migrate(context.Background())
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+
+		queries := rec.Queries()
+		if queries[len(queries)-2] != "ROLLBACK" || queries[len(queries)-1] != "SELECT RELEASE_LOCK(?)" {
+			t.Errorf("expected a rollback and a released lock but got %q", queries)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		run      func(m *migrate.Migrator) error
+		lock     int64
+		expected error
+	}{
+		{
+			"Locked",
+			func(m *migrate.Migrator) error {
+				_, err := m.Migrate(context.Background())
+				return err
+			},
+			0,
+			migrate.ErrLocked,
+		},
+		{
+			"DuplicateVersion",
+			func(m *migrate.Migrator) error {
+				_, err := m.Register(createUsers).Migrate(context.Background())
+				return err
+			},
+			1,
+			migrate.ErrDuplicateVersion,
+		},
+		{
+			"InvalidSteps",
+			func(m *migrate.Migrator) error {
+				_, err := m.Rollback(context.Background(), 0)
+				return err
+			},
+			1,
+			migrate.ErrInvalidSteps,
+		},
+		{
+			"NoDown",
+			func(m *migrate.Migrator) error {
+				_, err := m.Register(migrate.Migration{Version: failing.Version, Up: failing.Up}).Reset(context.Background())
+				return err
+			},
+			1,
+			migrate.ErrNoDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, rec := fakedb.Open()
+			defer db.Close()
+			rec.AddRows([]string{"lock"}, []driver.Value{tt.lock})
+			rec.AddRows([]string{"version", "batch"}, []driver.Value{failing.Version, int64(1)})
+
+			err := tt.run(migrate.New(db, mysql.NewMySQLQueryBuilder()).Register(createUsers))
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v but got %v", tt.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Package timestamp keeps the created and updated timestamp columns of
+// tables. A Registry belongs to a strategy instance: insert builders fill both
+// columns, update builders the updated one, unless the caller sets them.
+package timestamp
+
+import (
+	"sync"
+	"time"
+
+	"github.com/faciam-dev/goquent-query-builder/internal/common/structs"
+	"github.com/faciam-dev/goquent-query-builder/scope"
+)
+
+// Options configures the timestamps of a table.
+type Options struct {
+	// CreatedAt and UpdatedAt name the columns. They default to "created_at"
+	// and "updated_at"; "-" leaves the column alone.
+	CreatedAt string
+	UpdatedAt string
+	// Clock returns the time written to the columns. It defaults to time.Now.
+	Clock func() time.Time
+	// DatabaseClock writes CURRENT_TIMESTAMP instead of binding the time of
+	// Clock.
+	DatabaseClock bool
+}
+
+// Value returns the value the builders write to the timestamp columns.
+func (o Options) Value() interface{} {
+	if o.DatabaseClock {
+		return structs.Expression{SQL: "CURRENT_TIMESTAMP"}
+	}
+	return o.Clock()
+}
+
+// Registry holds the timestamp options of the tables of a strategy. It is safe
+// for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	tables map[string]Options
+}
+
+// Register enables the timestamps of table. Only the first of opts is used.
+func (r *Registry) Register(table string, opts ...Options) {
+	var o Options
+	if len(opts) > 0 {
+		o = opts[0]
+	}
+	o.CreatedAt = column(o.CreatedAt, "created_at")
+	o.UpdatedAt = column(o.UpdatedAt, "updated_at")
+	if o.Clock == nil {
+		o.Clock = time.Now
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tables == nil {
+		r.tables = make(map[string]Options)
+	}
+	r.tables[table] = o
+}
+
+// Lookup returns the timestamp options of the table a query is built on. An
+// empty column name means the column is not managed.
+func (r *Registry) Lookup(table string) (Options, bool) {
+	if r == nil {
+		return Options{}, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	o, ok := r.tables[scope.TableName(table)]
+	return o, ok
+}
+
+func column(name, def string) string {
+	switch name {
+	case "":
+		return def
+	case "-":
+		return ""
+	}
+	return name
+}